@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+// runCreateClient implements the "create-client" subcommand and returns the process exit code.
+// It is how the first admin client is bootstrapped.
+//
+//	hana-news-api create-client -name NAME -countries JP,CN [-admin] [-expires RFC3339]
+func runCreateClient(ctx context.Context, apiClientService *service.APIClientService, args []string) int {
+	fs := flag.NewFlagSet("create-client", flag.ContinueOnError)
+	name := fs.String("name", "", "Client name")
+	countries := fs.String("countries", "", "Comma-separated licensed countries (JP, CN), required")
+	admin := fs.Bool("admin", false, "Allow access to /v1/admin endpoints")
+	expires := fs.String("expires", "", "Key expiry (RFC3339); never if omitted")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	req := model.APIClientRequest{Name: *name, Admin: admin}
+	if *countries != "" {
+		req.Countries = strings.Split(*countries, ",")
+	}
+	if *expires != "" {
+		t, err := time.Parse(time.RFC3339, *expires)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -expires, must be RFC3339")
+			return 2
+		}
+		req.KeyExpiresAt = &t
+	}
+
+	client, err := apiClientService.Create(ctx, req)
+	if errors.Is(err, service.ErrInvalidAPIClient) {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to create client:", err)
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(client); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to encode client:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+// runImportInstruments implements the "import-instruments" subcommand and returns the process exit code.
+//
+//	hana-news-api import-instruments -file master.csv
+func runImportInstruments(ctx context.Context, instrumentService *service.InstrumentService, args []string) int {
+	fs := flag.NewFlagSet("import-instruments", flag.ContinueOnError)
+	file := fs.String("file", "-", "CSV master to import; '-' reads stdin")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var r io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "failed to open CSV:", err)
+			return 1
+		}
+		defer f.Close()
+		r = f
+	}
+
+	result, err := instrumentService.Import(ctx, r)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "import failed:", err)
+		return 1
+	}
+	fmt.Printf("imported %d instruments\n", result.Rows)
+	return 0
+}
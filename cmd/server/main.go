@@ -13,6 +13,7 @@ import (
 	_ "github.com/onelineai/hana-news-api/docs" // swagger docs
 
 	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/connector"
 	"github.com/onelineai/hana-news-api/internal/db"
 	"github.com/onelineai/hana-news-api/internal/handler"
 	"github.com/onelineai/hana-news-api/internal/repository"
@@ -36,6 +37,16 @@ import (
 
 // @schemes http https
 
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 Client API key. Streaming endpoints also accept it as the api_key query parameter.
+
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 "Bearer <JWT>" issued by the configured SSO, with AUTH_MODE=jwt
+
 func main() {
 	// Setup logger
 	logLevel := slog.LevelInfo
@@ -70,12 +81,66 @@ func main() {
 	silverRepo := repository.NewSilverRepository(database.Silver)
 	goldRepo := repository.NewGoldRepository(database.Gold)
 
+	// Register source connectors (sync order follows registration order)
+	connectors := connector.NewRegistry()
+	for _, c := range []connector.Connector{
+		connector.NewJPMinkabu(silverRepo),
+		connector.NewCNWind(silverRepo),
+	} {
+		if err := connectors.Register(c); err != nil {
+			logger.Error("failed to register connector", "error", err)
+			os.Exit(1)
+		}
+	}
+
 	// Initialize services
-	batchService := service.NewBatchService(silverRepo, goldRepo, logger)
-	newsService := service.NewNewsService(goldRepo)
+	batchService := service.NewBatchService(connectors, goldRepo, cfg.Batch, logger)
+	tickerService := service.NewTickerService(goldRepo, logger)
+	newsService := service.NewNewsService(goldRepo, tickerService)
+	instrumentService := service.NewInstrumentService(goldRepo, tickerService)
+	statsService := service.NewStatsService(goldRepo, tickerService)
+	newsBroker := service.NewNewsBroker(goldRepo, tickerService, cfg.Stream, logger)
+	webhookService := service.NewWebhookService(goldRepo, tickerService, cfg.Webhook, logger)
+	batchService.OnSynced(webhookService.Trigger)
+	syncRunService := service.NewSyncRunService(goldRepo)
+	retractionService := service.NewRetractionService(connectors, goldRepo, logger)
+	reconcileService := service.NewReconcileService(connectors, goldRepo, batchService, logger)
+	apiClientService := service.NewAPIClientService(goldRepo)
+	rateLimitService := service.NewRateLimitService(goldRepo, cfg.RateLimit, logger)
+	usageService := service.NewUsageService(goldRepo, logger)
+	tokenService := service.NewTokenService(cfg.Auth.JWT, logger)
+
+	// Run CLI subcommand instead of the server if one was given
+	if len(os.Args) > 1 {
+		var code int
+		switch os.Args[1] {
+		case "reconcile":
+			code = runReconcile(ctx, reconcileService, os.Args[2:])
+		case "import-instruments":
+			code = runImportInstruments(ctx, instrumentService, os.Args[2:])
+		case "create-client":
+			code = runCreateClient(ctx, apiClientService, os.Args[2:])
+		case "usage-report":
+			code = runUsageReport(ctx, usageService, os.Args[2:])
+		default:
+			fmt.Fprintln(os.Stderr, "unknown subcommand:", os.Args[1])
+			code = 2
+		}
+		database.Close()
+		os.Exit(code)
+	}
+
+	// Load JWT signing keys and keep them fresh in the background
+	if cfg.Auth.Mode == config.AuthModeJWT {
+		if err := tokenService.Load(ctx); err != nil {
+			logger.Error("failed to load JWKS", "error", err)
+			os.Exit(1)
+		}
+		tokenService.Start(ctx)
+	}
 
 	// Initialize scheduler
-	sched, err := scheduler.New(batchService, cfg.Batch.Interval, logger)
+	sched, err := scheduler.New(batchService, retractionService, reconcileService, cfg, logger)
 	if err != nil {
 		logger.Error("failed to create scheduler", "error", err)
 		os.Exit(1)
@@ -87,8 +152,42 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Start news broker for streaming clients
+	if err := newsBroker.Start(ctx); err != nil {
+		logger.Error("failed to start news broker", "error", err)
+		os.Exit(1)
+	}
+
+	// Start webhook delivery worker
+	if err := webhookService.Start(ctx); err != nil {
+		logger.Error("failed to start webhook worker", "error", err)
+		os.Exit(1)
+	}
+
+	// Start quota counter flushing and usage metering
+	rateLimitService.Start(ctx)
+	usageService.Start(ctx)
+
 	// Initialize HTTP handler
-	h := handler.New(newsService, database, logger)
+	h := handler.New(handler.Deps{
+		NewsService:       newsService,
+		InstrumentService: instrumentService,
+		StatsService:      statsService,
+		NewsBroker:        newsBroker,
+		WebhookService:    webhookService,
+		SyncRunService:    syncRunService,
+		BatchService:      batchService,
+		ReconcileService:  reconcileService,
+		APIClientService:  apiClientService,
+		RateLimitService:  rateLimitService,
+		UsageService:      usageService,
+		TokenService:      tokenService,
+		Scheduler:         sched,
+		DB:                database,
+		AuthConfig:        cfg.Auth,
+		TrustedProxies:    cfg.Server.TrustedProxies,
+		Logger:            logger,
+	})
 
 	// Setup HTTP server
 	srv := &http.Server{
@@ -126,10 +225,23 @@ func main() {
 		logger.Error("scheduler shutdown error", "error", err)
 	}
 
+	// Stop news broker (ends open streams so the server can drain)
+	newsBroker.Stop()
+
+	// Stop webhook worker (in-flight deliveries are retried after their lease expires)
+	webhookService.Stop()
+
 	// Shutdown HTTP server
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		logger.Error("HTTP server shutdown error", "error", err)
 	}
 
+	// Stop JWKS reloads
+	tokenService.Stop()
+
+	// Write the remaining quota counters and usage events
+	rateLimitService.Stop()
+	usageService.Stop()
+
 	logger.Info("shutdown complete")
 }
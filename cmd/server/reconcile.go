@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+// runReconcile implements the "reconcile" subcommand and returns the process exit code.
+//
+//	hana-news-api reconcile [-country JP|CN] [-window 24h | -from RFC3339 -to RFC3339] [-repair]
+func runReconcile(ctx context.Context, reconcileService *service.ReconcileService, args []string) int {
+	fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	country := fs.String("country", "", "Country code (JP or CN); all sources if omitted")
+	window := fs.Duration("window", 24*time.Hour, "Window ending now, used when -from is not set")
+	from := fs.String("from", "", "Window start (RFC3339)")
+	to := fs.String("to", "", "Window end (RFC3339, default now)")
+	repair := fs.Bool("repair", false, "Re-upsert missing and stale rows from silver and soft-delete extra rows")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	req := service.ReconcileRequest{To: time.Now(), Repair: *repair}
+	if *to != "" {
+		t, err := time.Parse(time.RFC3339, *to)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -to, must be RFC3339")
+			return 2
+		}
+		req.To = t
+	}
+	req.From = req.To.Add(-*window)
+	if *from != "" {
+		t, err := time.Parse(time.RFC3339, *from)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -from, must be RFC3339")
+			return 2
+		}
+		req.From = t
+	}
+	if *country != "" {
+		c := model.CountryCode(strings.ToUpper(*country))
+		if c != model.CountryJP && c != model.CountryCN {
+			fmt.Fprintln(os.Stderr, "invalid -country, must be 'JP' or 'CN'")
+			return 2
+		}
+		source := c.ToNewsSource()
+		req.Source = &source
+	}
+
+	reports, run, err := reconcileService.Start(ctx, req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to start reconciliation:", err)
+		return 1
+	}
+	runErr := run()
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(model.ReconcileTriggerResponse{Reports: reports}); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to encode reports:", err)
+		return 1
+	}
+
+	if runErr != nil {
+		fmt.Fprintln(os.Stderr, "reconciliation failed:", runErr)
+		return 1
+	}
+	return 0
+}
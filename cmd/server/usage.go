@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+// runUsageReport implements the "usage-report" subcommand and returns the process exit code.
+//
+//	hana-news-api usage-report [-month YYYY-MM] [-client ID] [-format csv|json] [-articles]
+func runUsageReport(ctx context.Context, usageService *service.UsageService, args []string) int {
+	fs := flag.NewFlagSet("usage-report", flag.ContinueOnError)
+	month := fs.String("month", "", "Month (YYYY-MM, Asia/Seoul); current month if omitted")
+	client := fs.String("client", "", "Client ID; all clients if omitted")
+	format := fs.String("format", string(model.UsageReportCSV), "Output format (csv or json)")
+	articles := fs.Bool("articles", false, "List the article details each client opened instead of endpoint totals")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	filter := model.UsageReportFilter{Month: time.Now()}
+	if *month != "" {
+		t, err := time.Parse("2006-01", *month)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -month, must be YYYY-MM")
+			return 2
+		}
+		filter.Month = t
+	}
+	if *client != "" {
+		filter.ClientID = client
+	}
+	if f := model.UsageReportFormat(*format); f != model.UsageReportCSV && f != model.UsageReportJSON {
+		fmt.Fprintln(os.Stderr, "invalid -format, must be 'csv' or 'json'")
+		return 2
+	}
+
+	var report any
+	var err error
+	if *articles {
+		report, err = usageService.ArticleReport(ctx, filter)
+	} else {
+		report, err = usageService.Report(ctx, filter)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to build usage report:", err)
+		return 1
+	}
+
+	if model.UsageReportFormat(*format) == model.UsageReportJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	} else {
+		switch r := report.(type) {
+		case *model.UsageReport:
+			err = service.WriteUsageReportCSV(os.Stdout, r)
+		case *model.UsageArticleReport:
+			err = service.WriteUsageArticleReportCSV(os.Stdout, r)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to write usage report:", err)
+		return 1
+	}
+	return 0
+}
@@ -24,7 +24,7 @@ const docTemplate = `{
     "paths": {
         "/health": {
             "get": {
-                "description": "Check service health status",
+                "description": "Check service health status. Status is \"degraded\" while any source's sync is failing or paused; the API itself keeps serving.",
                 "produces": [
                     "application/json"
                 ],
@@ -36,10 +36,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.HealthResponse"
                         }
                     },
                     "503": {
@@ -54,44 +51,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/news": {
+        "/v1/admin/clients": {
             "get": {
-                "description": "Get paginated list of translated news articles",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get paginated API clients without their keys, oldest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "admin"
                 ],
-                "summary": "List news",
+                "summary": "List API clients",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Country code (JP or CN)",
-                        "name": "country",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by ticker/stock code",
-                        "name": "ticker",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start time (RFC3339 format)",
-                        "name": "from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End time (RFC3339 format)",
-                        "name": "to",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
                         "description": "Page number (default: 1)",
@@ -109,7 +87,56 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsListResponse"
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.APIClientListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a client licensed for the given countries (required) and issue its first key. The key is only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create API client",
+                "parameters": [
+                    {
+                        "description": "Client settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.APIClientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.APIClient"
                         }
                     },
                     "400": {
@@ -133,9 +160,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/news/{id}": {
+        "/v1/admin/clients/{id}": {
             "get": {
-                "description": "Get detailed news article by UUID",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single client with its keys. Only key prefixes are returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get API client",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.APIClient"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a client's name. Omitted countries, admin and disabled fields keep their current state. Disabling a client rejects all of its keys.",
                 "consumes": [
                     "application/json"
                 ],
@@ -143,23 +231,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "admin"
                 ],
-                "summary": "Get news detail",
+                "summary": "Update API client",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "News UUID",
+                        "description": "Client ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Client settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.APIClientRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsDetail"
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.APIClient"
                         }
                     },
                     "400": {
@@ -191,89 +288,3381 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_onelineai_hana-news-api_internal_model.NewsDetail": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "keywords": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        },
+        "/v1/admin/clients/{id}/keys": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
-                },
-                "model_name": {
-                    "type": "string"
-                },
-                "original_content": {
-                    "type": "string"
-                },
-                "original_headline": {
-                    "type": "string"
-                },
-                "provider": {
-                    "type": "string"
-                },
-                "published_at": {
-                    "type": "string"
-                },
-                "source": {
-                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsSource"
-                },
-                "tickers": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+                ],
+                "description": "Issue a new key for a client. Set existing_key_ttl_hours to rotate: the client's other keys then expire after that grace period. The key is only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Key settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.APIKeyRequest"
+                        }
                     }
-                },
-                "topics": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.APIKey"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
-                },
-                "translated_content": {
-                    "type": "string"
-                },
-                "translated_headline": {
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_onelineai_hana-news-api_internal_model.NewsListItem": {
-            "type": "object",
-            "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "date": {
-                    "type": "string"
-                },
-                "headline": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "publisher": {
-                    "type": "string"
-                },
-                "time": {
-                    "type": "string"
                 }
             }
         },
-        "github_com_onelineai_hana-news-api_internal_model.NewsListResponse": {
-            "type": "object",
-            "properties": {
+        "/v1/admin/clients/{id}/keys/{keyID}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a key of a client immediately",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Revoke API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key ID",
+                        "name": "keyID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/clients/{id}/quota": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a client's request counters for today and a month, with a daily breakdown. Days are counted in Asia/Seoul. Counters are written every 10 seconds per replica, so the latest requests may be missing.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get API client quota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM, default: current month)",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.APIClientQuota"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/instruments/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upsert instruments from a CSV with a header row. Columns: ticker (required), exchange, isin, name_ko, name_ja, name_zh, aliases ('|'-separated). Blank cells keep stored values.",
+                "consumes": [
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Import instrument master",
+                "parameters": [
+                    {
+                        "description": "CSV master",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.InstrumentImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/reconcile": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare silver and gold over a time window and write a drift report per source. Poll the returned reports via /v1/admin/reconcile/reports/{id}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Trigger reconciliation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country code (JP or CN); all sources if omitted",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window start (RFC3339 format, default: 24 hours before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window end (RFC3339 format, default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Re-upsert missing and stale rows from silver and soft-delete extra rows",
+                        "name": "repair",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.ReconcileTriggerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/reconcile/reports": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated reconciliation reports without drift items, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List reconciliation reports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country code (JP or CN)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.ReconcileReportListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/reconcile/reports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a reconciliation report with its missing, extra and stale rows",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get reconciliation report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Report ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.ReconcileReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/sync": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start an immediate silver to gold sync for all sources or one country. Poll the returned runs via /v1/admin/sync/runs/{id}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Trigger sync",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country code (JP or CN); all sources if omitted",
+                        "name": "country",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SyncTriggerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/sync/backfill": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reset one country's sync cursor to the given time and re-sync from there. Poll the returned run via /v1/admin/sync/runs/{id}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Backfill source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country code (JP or CN)",
+                        "name": "country",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Re-sync silver rows updated at or after this time (RFC3339 format)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SyncTriggerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/sync/runs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated history of silver to gold sync runs, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List sync runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country code (JP or CN)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Run status (running, succeeded, failed, skipped)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SyncRunListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/sync/runs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single sync run by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get sync run",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sync run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SyncRun"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/usage": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Total a month of client requests per client and endpoint: requests, error responses, articles returned and distinct article details opened. Months are in Asia/Seoul.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Usage report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM, default: current month)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this client",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.UsageReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/usage/articles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the article details each client opened in a month with view counts. Months are in Asia/Seoul.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Usage article report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM, default: current month)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this client",
+                        "name": "client_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.UsageArticleReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated outbound webhooks, oldest first. Secrets are never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List webhooks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.WebhookListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a receiver for newly synced news matching the filters. Each delivery is a POST of model.WebhookPayload signed with X-Webhook-Signature: sha256=hex(HMAC-SHA256(secret, \"\u003cX-Webhook-Timestamp\u003e.\u003cbody\u003e\")). Non-2xx responses are retried with exponential backoff, then dead-lettered. The secret is only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single webhook by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.Webhook"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a webhook's URL and filters. An empty secret keeps the current one and an omitted active flag keeps the current state. A reactivated webhook skips news synced while it was inactive; use replay to catch up.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a webhook together with its pending deliveries and dead letters",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/webhooks/{id}/dead-letters": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated deliveries of a webhook that failed after all retries, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List webhook dead letters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.WebhookDeadLetterListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/webhooks/{id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue live news matching the webhook and published between from and to (at most 31 days) for delivery as \"news.replay\" events",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Replay webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Publish time range (RFC3339)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.WebhookReplayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.WebhookReplayResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/instruments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Autocomplete on ticker, ISIN or company name in Korean, Japanese or Chinese. Ticker prefix matches rank first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "instruments"
+                ],
+                "summary": "Search instruments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticker prefix, ISIN or part of a company name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.InstrumentListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/instruments/{code}/news": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Same as GET /v1/news restricted to one instrument. The code may be in any supported convention (7203, 7203.T, 600519.SS, ISIN).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "instruments"
+                ],
+                "summary": "List news for an instrument",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticker, alias or ISIN",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword search over Korean headline and content (terms of at least 2 characters)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time (RFC3339 format)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End time (RFC3339 format)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque next_cursor/prev_cursor from a previous response",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/news": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get paginated list of translated news articles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "List news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country code (JP or CN)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Ticker/stock codes, repeated or comma-separated; matches any",
+                        "name": "ticker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Topic filter, repeatable (max 20)",
+                        "name": "topic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "How repeated topics match: any (default) or all",
+                        "name": "topic_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Exclude news with any of these topics (max 20)",
+                        "name": "-topic",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Keyword filter, repeatable (max 20)",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "How repeated keywords match: any (default) or all",
+                        "name": "keyword_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Exclude news with any of these keywords (max 20)",
+                        "name": "-keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Keep news from any of these providers (max 20)",
+                        "name": "provider",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Exclude news from these providers (max 20)",
+                        "name": "-provider",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword search over Korean headline and content (all terms must match, at least 2 characters each)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyword search over original Japanese/Chinese headline and content (terms of at least 2 characters)",
+                        "name": "original_q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Exact Minkabu news_id or Wind object_id",
+                        "name": "source_news_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: relevance (default with q or original_q) or latest",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time (RFC3339 format)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End time (RFC3339 format)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque next_cursor/prev_cursor from a previous response; replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Total count: exact (default with page), estimate, or none (cursor only, its default)",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/news/search": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Same as GET /v1/news with filters in the request body, for watchlists too long for a URL",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Search news",
+                "parameters": [
+                    {
+                        "description": "Search filters",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/news/stream": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of news as soon as they are synced. Each \"news\" event carries a NewsListItem with the gold sync sequence as its ID; reconnect with Last-Event-ID to resume. A \"reset\" event means too much was missed to replay and the client should reload via GET /v1/news.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Stream news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country code (JP or CN)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Ticker/stock codes, repeated or comma-separated; matches any",
+                        "name": "ticker",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after this event ID",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Same as Last-Event-ID, for clients that cannot set headers",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsListItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/news/ws": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upgrades to a WebSocket speaking a JSON protocol. Clients send {\"type\":\"subscribe\"|\"unsubscribe\",\"id\":\"...\",\"tickers\":[...]} and get an \"ack\" with the same ID and the current subscriptions, or an error such as exceeding the per-connection subscription limit. News for subscribed tickers arrive as {\"type\":\"news\",\"seq\":...,\"news\":{...}}. The server sends a \"heartbeat\" every 30 seconds and answers client heartbeats. Slow clients whose queue overflows are closed with status 1013 and should reload via GET /v1/news before resubscribing.",
+                "tags": [
+                    "news"
+                ],
+                "summary": "Subscribe to news over WebSocket",
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.WSNewsMessage"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/news/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed news article by UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get news detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/tickers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count live news per ticker over a time window, most mentioned first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Most mentioned tickers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country code (JP or CN)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window start (RFC3339, default: 24h before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window end (RFC3339, default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tickers (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.TickerStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/tickers/{code}/histogram": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count live news mentioning a ticker per hour or day. Empty buckets are included with count 0.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Ticker news volume histogram",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticker in any supported convention",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bucket size: hour (default) or day",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone buckets align to, as known to the database (default: Asia/Seoul)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country code (JP or CN)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window start (RFC3339, default: 24h before to for hour, 30d for day)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window end (RFC3339, default: now)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.TickerHistogramResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stats/topics": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count live news per topic over a time window, most used first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Most used topics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Country code (JP or CN)",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window start (RFC3339, default: 24h before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Window end (RFC3339, default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of topics (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.TopicStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_onelineai_hana-news-api_internal_model.APIClient": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "type": "boolean"
+                },
+                "countries": {
+                    "description": "Countries lists the licensed news sources; empty means all",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.CountryCode"
+                    },
+                    "example": [
+                        "CN"
+                    ]
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.APIKey"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hana MTS"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.APIClientListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.APIClient"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.Pagination"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.APIClientQuota": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.QuotaUsage"
+                    }
+                },
+                "month": {
+                    "description": "Month totals the requested month, broken down in Daily (days without requests omitted)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.QuotaUsage"
+                        }
+                    ]
+                },
+                "timezone": {
+                    "description": "Timezone days are counted in",
+                    "type": "string",
+                    "example": "Asia/Seoul"
+                },
+                "today": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.QuotaUsage"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.APIClientRequest": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "description": "Admin and Disabled default to false on create and are kept on update if omitted",
+                    "type": "boolean"
+                },
+                "countries": {
+                    "description": "Countries is required on create and kept on update if omitted; an empty list is rejected",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "CN"
+                    ]
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "key_expires_at": {
+                    "description": "KeyExpiresAt sets the expiry of the first key; ignored on update",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hana MTS"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Key is the plaintext key, only returned when the key is created",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "hk_3f9a1c2e"
+                },
+                "revoked_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.APIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "existing_key_ttl_hours": {
+                    "description": "ExistingKeyTTLHours expires the client's other keys this many hours from now\n(0 revokes them immediately); omitted leaves them untouched",
+                    "type": "integer",
+                    "example": 24
+                },
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.ArrayMatch": {
+            "type": "string",
+            "enum": [
+                "any",
+                "all"
+            ],
+            "x-enum-varnames": [
+                "MatchAny",
+                "MatchAll"
+            ]
+        },
+        "github_com_onelineai_hana-news-api_internal_model.CountryCode": {
+            "type": "string",
+            "enum": [
+                "JP",
+                "CN"
+            ],
+            "x-enum-varnames": [
+                "CountryJP",
+                "CountryCN"
+            ]
+        },
+        "github_com_onelineai_hana-news-api_internal_model.DriftItem": {
+            "type": "object",
+            "properties": {
+                "gold_updated_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.DriftKind"
+                },
+                "repaired": {
+                    "type": "boolean"
+                },
+                "silver_updated_at": {
+                    "type": "string"
+                },
+                "source_news_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.DriftKind": {
+            "type": "string",
+            "enum": [
+                "missing",
+                "extra",
+                "stale"
+            ],
+            "x-enum-comments": {
+                "DriftExtra": "live in gold, gone from silver",
+                "DriftMissing": "in silver, not in gold",
+                "DriftStale": "in both, but silver is newer or its content hash differs from gold"
+            },
+            "x-enum-descriptions": [
+                "in silver, not in gold",
+                "live in gold, gone from silver",
+                "in both, but silver is newer or its content hash differs from gold"
+            ],
+            "x-enum-varnames": [
+                "DriftMissing",
+                "DriftExtra",
+                "DriftStale"
+            ]
+        },
+        "github_com_onelineai_hana-news-api_internal_model.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "sources": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SourceHealth"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.HistogramBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.Instrument": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exchange": {
+                    "type": "string",
+                    "example": "TSE"
+                },
+                "first_seen_at": {
+                    "description": "FirstSeenAt and LastSeenAt span the news mentioning the ticker; nil if never seen",
+                    "type": "string"
+                },
+                "isin": {
+                    "type": "string",
+                    "example": "JP3633400001"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "name_ja": {
+                    "type": "string",
+                    "example": "トヨタ自動車"
+                },
+                "name_ko": {
+                    "type": "string",
+                    "example": "도요타자동차"
+                },
+                "name_zh": {
+                    "type": "string",
+                    "example": "丰田汽车"
+                },
+                "ticker": {
+                    "type": "string",
+                    "example": "7203.T"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.InstrumentImportResult": {
+            "type": "object",
+            "properties": {
+                "rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.InstrumentListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.Instrument"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.Pagination"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.NewsCount": {
+            "type": "string",
+            "enum": [
+                "exact",
+                "estimate",
+                "none"
+            ],
+            "x-enum-comments": {
+                "CountEstimate": "planner row estimate",
+                "CountExact": "COUNT(*), default for page/limit",
+                "CountNone": "no total, cursors only and their default"
+            },
+            "x-enum-descriptions": [
+                "COUNT(*), default for page/limit",
+                "planner row estimate",
+                "no total, cursors only and their default"
+            ],
+            "x-enum-varnames": [
+                "CountExact",
+                "CountEstimate",
+                "CountNone"
+            ]
+        },
+        "github_com_onelineai_hana-news-api_internal_model.NewsDetail": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "model_name": {
+                    "type": "string"
+                },
+                "original_content": {
+                    "type": "string"
+                },
+                "original_headline": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "source": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsSource"
+                },
+                "tickers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.Instrument"
+                    }
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "translated_content": {
+                    "type": "string"
+                },
+                "translated_headline": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.NewsListItem": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "headline": {
+                    "type": "string"
+                },
+                "highlight": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SearchHighlight"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "matched_tickers": {
+                    "description": "MatchedTickers lists the requested tickers the item mentions",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "publisher": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "Score and Highlight are only set for keyword searches",
+                    "type": "number"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.NewsListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsListItem"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsPagination"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.NewsPagination": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_estimated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.NewsSearchRequest": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "enum": [
+                        "exact",
+                        "estimate",
+                        "none"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsCount"
+                        }
+                    ]
+                },
+                "country": {
+                    "type": "string",
+                    "example": "JP"
+                },
+                "cursor": {
+                    "type": "string"
+                },
+                "exclude_keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exclude_providers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exclude_topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "keyword_match": {
+                    "enum": [
+                        "any",
+                        "all"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.ArrayMatch"
+                        }
+                    ]
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "original_q": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "providers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "q": {
+                    "type": "string"
+                },
+                "sort": {
+                    "enum": [
+                        "latest",
+                        "relevance"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsSort"
+                        }
+                    ]
+                },
+                "source_news_id": {
+                    "type": "string"
+                },
+                "tickers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "7203",
+                        "9984"
+                    ]
+                },
+                "to": {
+                    "type": "string"
+                },
+                "topic_match": {
+                    "enum": [
+                        "any",
+                        "all"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.ArrayMatch"
+                        }
+                    ]
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.NewsSort": {
+            "type": "string",
+            "enum": [
+                "latest",
+                "relevance"
+            ],
+            "x-enum-comments": {
+                "SortRelevance": "only meaningful with a keyword query"
+            },
+            "x-enum-descriptions": [
+                "",
+                "only meaningful with a keyword query"
+            ],
+            "x-enum-varnames": [
+                "SortLatest",
+                "SortRelevance"
+            ]
+        },
+        "github_com_onelineai_hana-news-api_internal_model.NewsSource": {
+            "type": "string",
+            "enum": [
+                "jp_minkabu",
+                "cn_wind"
+            ],
+            "x-enum-varnames": [
+                "SourceJPMinkabu",
+                "SourceCNWind"
+            ]
+        },
+        "github_com_onelineai_hana-news-api_internal_model.Pagination": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.QuotaUsage": {
+            "type": "object",
+            "properties": {
+                "period": {
+                    "type": "string",
+                    "example": "2026-01-29"
+                },
+                "rate_limited": {
+                    "description": "RateLimited counts requests rejected with 429, which are not in Requests",
+                    "type": "integer"
+                },
+                "requests": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.ReconcileReport": {
+            "type": "object",
+            "properties": {
+                "drift": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.DriftItem"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "extra_count": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "gold_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "missing_count": {
+                    "type": "integer"
+                },
+                "repair": {
+                    "type": "boolean"
+                },
+                "repaired_count": {
+                    "type": "integer"
+                },
+                "silver_count": {
+                    "type": "integer"
+                },
+                "source": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsSource"
+                },
+                "stale_count": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.ReconcileStatus"
+                },
+                "window_from": {
+                    "type": "string"
+                },
+                "window_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.ReconcileReportListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.ReconcileReport"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.Pagination"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.ReconcileStatus": {
+            "type": "string",
+            "enum": [
+                "running",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ReconcileRunning",
+                "ReconcileSucceeded",
+                "ReconcileFailed"
+            ]
+        },
+        "github_com_onelineai_hana-news-api_internal_model.ReconcileTriggerResponse": {
+            "type": "object",
+            "properties": {
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.ReconcileReport"
+                    }
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.SearchHighlight": {
+            "type": "object",
+            "properties": {
+                "headline": {
+                    "type": "string"
+                },
+                "snippet": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.SourceHealth": {
+            "type": "object",
+            "properties": {
+                "consecutive_failures": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_success_at": {
+                    "type": "string"
+                },
+                "paused_until": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SourceHealthStatus"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.SourceHealthStatus": {
+            "type": "string",
+            "enum": [
+                "ok",
+                "degraded",
+                "paused"
+            ],
+            "x-enum-comments": {
+                "SourceDegraded": "last run(s) failed",
+                "SourcePaused": "circuit breaker open, syncs skipped"
+            },
+            "x-enum-descriptions": [
+                "",
+                "last run(s) failed",
+                "circuit breaker open, syncs skipped"
+            ],
+            "x-enum-varnames": [
+                "SourceHealthy",
+                "SourceDegraded",
+                "SourcePaused"
+            ]
+        },
+        "github_com_onelineai_hana-news-api_internal_model.StatsInterval": {
+            "type": "string",
+            "enum": [
+                "hour",
+                "day"
+            ],
+            "x-enum-varnames": [
+                "IntervalHour",
+                "IntervalDay"
+            ]
+        },
+        "github_com_onelineai_hana-news-api_internal_model.SyncCursor": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.SyncRun": {
+            "type": "object",
+            "properties": {
+                "cursor_after": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SyncCursor"
+                },
+                "cursor_before": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SyncCursor"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rows_duplicate": {
+                    "description": "older copy of a row repeated in the same batch, skipped",
+                    "type": "integer"
+                },
+                "rows_fetched": {
+                    "type": "integer"
+                },
+                "rows_inserted": {
+                    "type": "integer"
+                },
+                "rows_unchanged": {
+                    "description": "content hash matched, row not rewritten",
+                    "type": "integer"
+                },
+                "rows_updated": {
+                    "type": "integer"
+                },
+                "source": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsSource"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SyncRunStatus"
+                },
+                "trigger": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SyncTrigger"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.SyncRunListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SyncRun"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.Pagination"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.SyncRunStatus": {
+            "type": "string",
+            "enum": [
+                "running",
+                "succeeded",
+                "failed",
+                "skipped"
+            ],
+            "x-enum-comments": {
+                "SyncRunSkipped": "source paused by its circuit breaker"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "",
+                "source paused by its circuit breaker"
+            ],
+            "x-enum-varnames": [
+                "SyncRunRunning",
+                "SyncRunSucceeded",
+                "SyncRunFailed",
+                "SyncRunSkipped"
+            ]
+        },
+        "github_com_onelineai_hana-news-api_internal_model.SyncTrigger": {
+            "type": "string",
+            "enum": [
+                "scheduled",
+                "manual",
+                "backfill"
+            ],
+            "x-enum-varnames": [
+                "SyncTriggerScheduled",
+                "SyncTriggerManual",
+                "SyncTriggerBackfill"
+            ]
+        },
+        "github_com_onelineai_hana-news-api_internal_model.SyncTriggerResponse": {
+            "type": "object",
+            "properties": {
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.SyncRun"
+                    }
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.TickerCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "name_ja": {
+                    "type": "string"
+                },
+                "name_ko": {
+                    "type": "string"
+                },
+                "name_zh": {
+                    "type": "string"
+                },
+                "ticker": {
+                    "type": "string",
+                    "example": "7203.T"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.TickerHistogramResponse": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.HistogramBucket"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "interval": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.StatsInterval"
+                },
+                "ticker": {
+                    "type": "string",
+                    "example": "7203.T"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Seoul"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.TickerStatsResponse": {
+            "type": "object",
+            "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsListItem"
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.TickerCount"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.TopicCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "topic": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.TopicStatsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.TopicCount"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.UsageArticleReport": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.UsageArticleRow"
+                    }
+                },
+                "month": {
+                    "type": "string",
+                    "example": "2026-01"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Seoul"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.UsageArticleRow": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "client_name": {
+                    "type": "string",
+                    "example": "Hana MTS"
+                },
+                "first_viewed_at": {
+                    "type": "string"
+                },
+                "last_viewed_at": {
+                    "type": "string"
+                },
+                "news_id": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.UsageReport": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.UsageReportRow"
+                    }
+                },
+                "month": {
+                    "type": "string",
+                    "example": "2026-01"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Seoul"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.UsageReportRow": {
+            "type": "object",
+            "properties": {
+                "articles": {
+                    "description": "Articles is the number of articles returned",
+                    "type": "integer"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "client_name": {
+                    "type": "string",
+                    "example": "Hana MTS"
+                },
+                "endpoint": {
+                    "type": "string",
+                    "example": "GET /v1/news"
+                },
+                "errors": {
+                    "description": "Errors counts requests answered with a 4xx or 5xx status",
+                    "type": "integer"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "unique_articles": {
+                    "description": "UniqueArticles is the number of distinct articles whose detail was returned",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.WSNewsMessage": {
+            "type": "object",
+            "properties": {
+                "news": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.NewsListItem"
+                },
+                "seq": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "type": {
+                    "type": "string",
+                    "example": "news"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "country": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.CountryCode"
+                        }
+                    ],
+                    "example": "JP"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret is the HMAC-SHA256 signing key, only returned when the webhook is created",
+                    "type": "string"
+                },
+                "tickers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/news"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.WebhookDeadLetter": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.WebhookEvent"
+                },
+                "failed_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status": {
+                    "type": "integer"
+                },
+                "news_id": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.WebhookDeadLetterListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.WebhookDeadLetter"
                     }
                 },
                 "pagination": {
@@ -281,30 +3670,110 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_onelineai_hana-news-api_internal_model.NewsSource": {
+        "github_com_onelineai_hana-news-api_internal_model.WebhookEvent": {
             "type": "string",
             "enum": [
-                "jp_minkabu",
-                "cn_wind"
+                "news.synced",
+                "news.replay"
+            ],
+            "x-enum-comments": {
+                "WebhookEventReplay": "re-sent by an admin replay",
+                "WebhookEventSynced": "news inserted or updated by a sync"
+            },
+            "x-enum-descriptions": [
+                "news inserted or updated by a sync",
+                "re-sent by an admin replay"
             ],
             "x-enum-varnames": [
-                "SourceJPMinkabu",
-                "SourceCNWind"
+                "WebhookEventSynced",
+                "WebhookEventReplay"
             ]
         },
-        "github_com_onelineai_hana-news-api_internal_model.Pagination": {
+        "github_com_onelineai_hana-news-api_internal_model.WebhookListResponse": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.Webhook"
+                    }
                 },
-                "page": {
-                    "type": "integer"
+                "pagination": {
+                    "$ref": "#/definitions/github_com_onelineai_hana-news-api_internal_model.Pagination"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.WebhookReplayRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
                 },
-                "total": {
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.WebhookReplayResponse": {
+            "type": "object",
+            "properties": {
+                "queued": {
                     "type": "integer"
                 }
             }
+        },
+        "github_com_onelineai_hana-news-api_internal_model.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active defaults to true on create and is kept on update if omitted",
+                    "type": "boolean"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "JP"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret is generated on create if empty and kept on update if empty",
+                    "type": "string"
+                },
+                "tickers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "7203.T"
+                    ]
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/news"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Client API key. Streaming endpoints also accept it as the api_key query parameter.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "\"Bearer \u003cJWT\u003e\" issued by the configured SSO, with AUTH_MODE=jwt",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,38 @@
+// Package auth carries the authenticated API client through request contexts
+package auth
+
+import (
+	"context"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+type contextKey struct{}
+
+// WithClient returns a context carrying the authenticated client
+func WithClient(ctx context.Context, client *model.APIClient) context.Context {
+	return context.WithValue(ctx, contextKey{}, client)
+}
+
+// ClientFromContext returns the authenticated client, or nil if the request
+// was not authenticated (auth disabled or background work)
+func ClientFromContext(ctx context.Context) *model.APIClient {
+	client, _ := ctx.Value(contextKey{}).(*model.APIClient)
+	return client
+}
+
+// AllowedSources returns the news sources the caller is licensed for, or nil if
+// it may read every source
+func AllowedSources(ctx context.Context) []model.NewsSource {
+	client := ClientFromContext(ctx)
+	if client == nil || len(client.Countries) == 0 {
+		return nil
+	}
+	return client.Sources()
+}
+
+// IsAdmin reports whether the caller is an authenticated admin client
+func IsAdmin(ctx context.Context) bool {
+	client := ClientFromContext(ctx)
+	return client != nil && client.Admin
+}
@@ -1,24 +1,36 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
+	"net/netip"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server ServerConfig
-	Silver DBConfig
-	Gold   DBConfig
-	Batch  BatchConfig
+	Server    ServerConfig
+	Silver    DBConfig
+	Gold      DBConfig
+	Batch     BatchConfig
+	Reconcile ReconcileConfig
+	Stream    StreamConfig
+	Webhook   WebhookConfig
+	Auth      AuthConfig
+	RateLimit RateLimitConfig
 }
 
 type ServerConfig struct {
 	Port     int
 	LogLevel string
+	// TrustedProxies are the proxy networks whose X-Forwarded-For and X-Real-IP headers
+	// are believed; other peers are identified by their socket address
+	TrustedProxies []netip.Prefix
 }
 
 type DBConfig struct {
@@ -32,8 +44,111 @@ type DBConfig struct {
 
 type BatchConfig struct {
 	Interval time.Duration
+	// Parallel syncs sources concurrently instead of one after another
+	Parallel bool
+	// MaxRetries is the number of retries per source within one run
+	MaxRetries   int
+	RetryBackoff time.Duration
+	// BreakerThreshold consecutive failed runs pause a source for BreakerCooldown
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// BulkThreshold is the pending row count above which a sync switches to COPY-based
+	// bulk loading in pages of BulkSize (0 disables)
+	BulkThreshold int
+	BulkSize      int
+	// RetractionInterval is how often gold is checked for rows removed from silver (0 disables)
+	RetractionInterval time.Duration
 }
 
+type ReconcileConfig struct {
+	// Interval is how often the scheduled reconciliation runs (0 disables)
+	Interval time.Duration
+	// Window is how far back the scheduled reconciliation looks
+	Window     time.Duration
+	AutoRepair bool
+}
+
+type StreamConfig struct {
+	// MaxSubscriptions is the number of tickers one WebSocket connection may subscribe to
+	MaxSubscriptions int
+}
+
+type WebhookConfig struct {
+	// Workers is the number of deliveries sent concurrently per replica
+	Workers int
+	// MaxAttempts failed attempts move a delivery to the dead letters
+	MaxAttempts int
+	// RetryBackoff is the wait after the first failure, doubled for each further one
+	RetryBackoff time.Duration
+	Timeout      time.Duration
+	// PollInterval is how often due retries and news written by other replicas are picked up
+	PollInterval time.Duration
+	// SecretKey is the AES-256 key that encrypts webhook signing secrets at rest.
+	// Without one, secrets are stored in plaintext.
+	SecretKey []byte
+}
+
+// AuthMode selects how API callers are authenticated
+type AuthMode string
+
+const (
+	AuthModeNone   AuthMode = "none"    // no authentication; local development only
+	AuthModeAPIKey AuthMode = "api_key" // X-API-Key checked against gold.api_client_keys
+	AuthModeJWT    AuthMode = "jwt"     // bearer JWTs checked against a JWKS, in addition to API keys
+)
+
+type AuthConfig struct {
+	Mode AuthMode
+	// AnonymousAdmin lets unauthenticated callers use admin endpoints with AUTH_MODE=none
+	AnonymousAdmin bool
+	// AllowedOrigins is the CORS and WebSocket origin allow list; empty allows same-origin
+	// calls only and "*" allows any origin
+	AllowedOrigins []string
+	JWT            JWTConfig
+}
+
+type JWTConfig struct {
+	// JWKSURL or JWKSFile is where the signing keys are loaded from
+	JWKSURL  string
+	JWKSFile string
+	// JWKSRefresh is how often the keys are reloaded; unknown key IDs reload them sooner
+	JWKSRefresh time.Duration
+	// Issuer and Audience are checked if set
+	Issuer   string
+	Audience string
+	// CountriesClaim lists the licensed countries (JP, CN). Tokens without it are
+	// rejected unless AllowAllCountries grants them every source.
+	CountriesClaim    string
+	AllowAllCountries bool
+	// RolesClaim lists the caller's roles; AdminRole grants admin access.
+	// Claims may be dotted paths such as realm_access.roles.
+	RolesClaim string
+	AdminRole  string
+	// ClientID is the API client token callers are rate limited and metered as (optional)
+	ClientID string
+}
+
+// RateLimitStore selects where rate limit token buckets are kept
+type RateLimitStore string
+
+const (
+	RateLimitStoreMemory   RateLimitStore = "memory"   // per replica
+	RateLimitStorePostgres RateLimitStore = "postgres" // shared by all replicas in gold.rate_limit_buckets
+)
+
+type RateLimitConfig struct {
+	Store RateLimitStore
+	// ClientRate is the sustained requests per second of one API client (0 disables)
+	ClientRate  int
+	ClientBurst int
+	// IPRate is the sustained requests per second from one IP address (0 disables)
+	IPRate  int
+	IPBurst int
+}
+
+// uuidPattern matches a UUID in canonical 8-4-4-4-12 hex form
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 func (d DBConfig) DSN() string {
 	return fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?search_path=%s&sslmode=disable",
@@ -51,6 +166,13 @@ func Load() (*Config, error) {
 	// Server config
 	cfg.Server.Port = getEnvAsInt("SERVER_PORT", 8080)
 	cfg.Server.LogLevel = getEnv("LOG_LEVEL", "info")
+	for _, cidr := range getEnvAsSlice("TRUSTED_PROXIES", nil) {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q: %w", cidr, err)
+		}
+		cfg.Server.TrustedProxies = append(cfg.Server.TrustedProxies, prefix.Masked())
+	}
 
 	// Silver DB config
 	cfg.Silver.Host = getEnv("SILVER_DB_HOST", "localhost")
@@ -71,6 +193,75 @@ func Load() (*Config, error) {
 	// Batch config
 	intervalMinutes := getEnvAsInt("BATCH_INTERVAL_MINUTES", 10)
 	cfg.Batch.Interval = time.Duration(intervalMinutes) * time.Minute
+	cfg.Batch.Parallel = getEnvAsBool("BATCH_PARALLEL", false)
+	cfg.Batch.MaxRetries = getEnvAsInt("BATCH_MAX_RETRIES", 3)
+	cfg.Batch.RetryBackoff = time.Duration(getEnvAsInt("BATCH_RETRY_BACKOFF_SECONDS", 5)) * time.Second
+	cfg.Batch.BreakerThreshold = getEnvAsInt("BATCH_BREAKER_THRESHOLD", 3)
+	cfg.Batch.BreakerCooldown = time.Duration(getEnvAsInt("BATCH_BREAKER_COOLDOWN_MINUTES", 30)) * time.Minute
+	cfg.Batch.BulkThreshold = getEnvAsInt("BATCH_BULK_THRESHOLD", 20000)
+	cfg.Batch.BulkSize = getEnvAsInt("BATCH_BULK_SIZE", 5000)
+	cfg.Batch.RetractionInterval = time.Duration(getEnvAsInt("RETRACTION_SWEEP_INTERVAL_MINUTES", 60)) * time.Minute
+
+	// Reconcile config
+	cfg.Reconcile.Interval = time.Duration(getEnvAsInt("RECONCILE_INTERVAL_MINUTES", 360)) * time.Minute
+	cfg.Reconcile.Window = time.Duration(getEnvAsInt("RECONCILE_WINDOW_HOURS", 24)) * time.Hour
+	cfg.Reconcile.AutoRepair = getEnvAsBool("RECONCILE_AUTO_REPAIR", false)
+
+	// Stream config
+	cfg.Stream.MaxSubscriptions = getEnvAsInt("WS_MAX_SUBSCRIPTIONS", 100)
+
+	// Webhook config
+	cfg.Webhook.Workers = getEnvAsInt("WEBHOOK_WORKERS", 4)
+	cfg.Webhook.MaxAttempts = getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 8)
+	cfg.Webhook.RetryBackoff = time.Duration(getEnvAsInt("WEBHOOK_RETRY_BACKOFF_SECONDS", 30)) * time.Second
+	cfg.Webhook.Timeout = time.Duration(getEnvAsInt("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second
+	cfg.Webhook.PollInterval = time.Duration(getEnvAsInt("WEBHOOK_POLL_INTERVAL_SECONDS", 30)) * time.Second
+	if key := getEnv("WEBHOOK_SECRET_KEY", ""); key != "" {
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil || len(decoded) != 32 {
+			return nil, fmt.Errorf("WEBHOOK_SECRET_KEY must be 32 bytes in base64")
+		}
+		cfg.Webhook.SecretKey = decoded
+	}
+
+	// Auth config
+	cfg.Auth.Mode = AuthMode(getEnv("AUTH_MODE", string(AuthModeAPIKey)))
+	if cfg.Auth.Mode != AuthModeNone && cfg.Auth.Mode != AuthModeAPIKey && cfg.Auth.Mode != AuthModeJWT {
+		return nil, fmt.Errorf("invalid AUTH_MODE %q", cfg.Auth.Mode)
+	}
+	cfg.Auth.AnonymousAdmin = getEnvAsBool("AUTH_ANONYMOUS_ADMIN", false)
+	if cfg.Auth.AnonymousAdmin && cfg.Auth.Mode != AuthModeNone {
+		return nil, fmt.Errorf("AUTH_ANONYMOUS_ADMIN requires AUTH_MODE=none")
+	}
+	cfg.Auth.AllowedOrigins = getEnvAsSlice("CORS_ALLOWED_ORIGINS", nil)
+
+	// JWT config
+	cfg.Auth.JWT.JWKSURL = getEnv("JWT_JWKS_URL", "")
+	cfg.Auth.JWT.JWKSFile = getEnv("JWT_JWKS_FILE", "")
+	if cfg.Auth.Mode == AuthModeJWT && cfg.Auth.JWT.JWKSURL == "" && cfg.Auth.JWT.JWKSFile == "" {
+		return nil, fmt.Errorf("AUTH_MODE=jwt requires JWT_JWKS_URL or JWT_JWKS_FILE")
+	}
+	cfg.Auth.JWT.JWKSRefresh = time.Duration(getEnvAsInt("JWT_JWKS_REFRESH_MINUTES", 60)) * time.Minute
+	cfg.Auth.JWT.Issuer = getEnv("JWT_ISSUER", "")
+	cfg.Auth.JWT.Audience = getEnv("JWT_AUDIENCE", "")
+	cfg.Auth.JWT.CountriesClaim = getEnv("JWT_COUNTRIES_CLAIM", "countries")
+	cfg.Auth.JWT.AllowAllCountries = getEnvAsBool("JWT_ALLOW_ALL_COUNTRIES", false)
+	cfg.Auth.JWT.RolesClaim = getEnv("JWT_ROLES_CLAIM", "roles")
+	cfg.Auth.JWT.AdminRole = getEnv("JWT_ADMIN_ROLE", "admin")
+	cfg.Auth.JWT.ClientID = getEnv("JWT_CLIENT_ID", "")
+	if cfg.Auth.JWT.ClientID != "" && !uuidPattern.MatchString(cfg.Auth.JWT.ClientID) {
+		return nil, fmt.Errorf("JWT_CLIENT_ID must be the UUID of an API client, got %q", cfg.Auth.JWT.ClientID)
+	}
+
+	// Rate limit config
+	cfg.RateLimit.Store = RateLimitStore(getEnv("RATE_LIMIT_STORE", string(RateLimitStoreMemory)))
+	if cfg.RateLimit.Store != RateLimitStoreMemory && cfg.RateLimit.Store != RateLimitStorePostgres {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_STORE %q", cfg.RateLimit.Store)
+	}
+	cfg.RateLimit.ClientRate = getEnvAsInt("RATE_LIMIT_CLIENT_RPS", 10)
+	cfg.RateLimit.ClientBurst = getEnvAsInt("RATE_LIMIT_CLIENT_BURST", 20)
+	cfg.RateLimit.IPRate = getEnvAsInt("RATE_LIMIT_IP_RPS", 20)
+	cfg.RateLimit.IPBurst = getEnvAsInt("RATE_LIMIT_IP_BURST", 40)
 
 	return cfg, nil
 }
@@ -90,3 +281,27 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	if value, exists := os.LookupEnv(key); exists {
+		var values []string
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		if len(values) > 0 {
+			return values
+		}
+	}
+	return defaultValue
+}
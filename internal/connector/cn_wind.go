@@ -0,0 +1,46 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+// CNWind reads silver.cn_wind_translated_news
+type CNWind struct {
+	silverRepo *repository.SilverRepository
+}
+
+func NewCNWind(silverRepo *repository.SilverRepository) *CNWind {
+	return &CNWind{silverRepo: silverRepo}
+}
+
+func (c *CNWind) Source() model.NewsSource {
+	return model.SourceCNWind
+}
+
+func (c *CNWind) FetchSince(ctx context.Context, after *model.SyncCursor, limit int) (*Batch, error) {
+	news, err := c.silverRepo.GetCNWindNewsSince(ctx, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &Batch{News: make([]*model.TranslatedNews, len(news))}
+	for i := range news {
+		batch.News[i] = news[i].ToTranslatedNews()
+	}
+	if len(news) > 0 {
+		last := news[len(news)-1]
+		batch.Next = model.SyncCursor{UpdatedAt: last.UpdatedAt, ID: last.ID}
+	}
+	return batch, nil
+}
+
+func (c *CNWind) ExistingIDs(ctx context.Context, sourceNewsIDs []string) ([]string, error) {
+	return c.silverRepo.GetExistingCNWindObjectIDs(ctx, sourceNewsIDs)
+}
+
+func (c *CNWind) CountSince(ctx context.Context, after *model.SyncCursor, limit int) (int, error) {
+	return c.silverRepo.CountCNWindNewsSince(ctx, after, limit)
+}
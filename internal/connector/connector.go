@@ -0,0 +1,39 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// Batch is a page of silver records converted to the unified gold format
+type Batch struct {
+	News []*model.TranslatedNews
+	// Next is the cursor of the last silver row in the batch
+	Next model.SyncCursor
+}
+
+// Connector fetches news for a single source from silver
+type Connector interface {
+	// Source returns the news source this connector serves
+	Source() model.NewsSource
+	// FetchSince returns up to limit records positioned after the cursor, ordered by (updated_at, id).
+	// A nil cursor fetches from the beginning.
+	FetchSince(ctx context.Context, after *model.SyncCursor, limit int) (*Batch, error)
+}
+
+// Retractable is implemented by connectors that can tell which gold rows still exist in silver.
+// Gold rows whose source row is gone are treated as retracted. Silver has no retraction
+// flag, so articles withdrawn upstream are only detected once the ETL deletes the row.
+type Retractable interface {
+	Connector
+	// ExistingIDs returns the subset of sourceNewsIDs that are still live in silver
+	ExistingIDs(ctx context.Context, sourceNewsIDs []string) ([]string, error)
+}
+
+// BacklogCounter is implemented by connectors that can estimate how many rows are pending
+type BacklogCounter interface {
+	Connector
+	// CountSince counts records positioned after the cursor, stopping at limit
+	CountSince(ctx context.Context, after *model.SyncCursor, limit int) (int, error)
+}
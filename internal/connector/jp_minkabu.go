@@ -0,0 +1,46 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+// JPMinkabu reads silver.jp_minkabu_translated_news
+type JPMinkabu struct {
+	silverRepo *repository.SilverRepository
+}
+
+func NewJPMinkabu(silverRepo *repository.SilverRepository) *JPMinkabu {
+	return &JPMinkabu{silverRepo: silverRepo}
+}
+
+func (c *JPMinkabu) Source() model.NewsSource {
+	return model.SourceJPMinkabu
+}
+
+func (c *JPMinkabu) FetchSince(ctx context.Context, after *model.SyncCursor, limit int) (*Batch, error) {
+	news, err := c.silverRepo.GetJPMinkabuNewsSince(ctx, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &Batch{News: make([]*model.TranslatedNews, len(news))}
+	for i := range news {
+		batch.News[i] = news[i].ToTranslatedNews()
+	}
+	if len(news) > 0 {
+		last := news[len(news)-1]
+		batch.Next = model.SyncCursor{UpdatedAt: last.UpdatedAt, ID: last.ID}
+	}
+	return batch, nil
+}
+
+func (c *JPMinkabu) ExistingIDs(ctx context.Context, sourceNewsIDs []string) ([]string, error) {
+	return c.silverRepo.GetExistingJPMinkabuNewsIDs(ctx, sourceNewsIDs)
+}
+
+func (c *JPMinkabu) CountSince(ctx context.Context, after *model.SyncCursor, limit int) (int, error) {
+	return c.silverRepo.CountJPMinkabuNewsSince(ctx, after, limit)
+}
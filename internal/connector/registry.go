@@ -0,0 +1,43 @@
+package connector
+
+import (
+	"fmt"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// Registry holds the connectors keyed by news source, preserving registration order
+type Registry struct {
+	connectors map[model.NewsSource]Connector
+	order      []model.NewsSource
+}
+
+func NewRegistry() *Registry {
+	return &Registry{connectors: make(map[model.NewsSource]Connector)}
+}
+
+// Register adds a connector. Registering the same source twice is an error.
+func (r *Registry) Register(c Connector) error {
+	source := c.Source()
+	if _, exists := r.connectors[source]; exists {
+		return fmt.Errorf("connector already registered for source %q", source)
+	}
+	r.connectors[source] = c
+	r.order = append(r.order, source)
+	return nil
+}
+
+// Get returns the connector for a source
+func (r *Registry) Get(source model.NewsSource) (Connector, bool) {
+	c, ok := r.connectors[source]
+	return c, ok
+}
+
+// All returns every registered connector in registration order
+func (r *Registry) All() []Connector {
+	all := make([]Connector, 0, len(r.order))
+	for _, source := range r.order {
+		all = append(all, r.connectors[source])
+	}
+	return all
+}
@@ -0,0 +1,93 @@
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// stubConnector serves a source without reading silver
+type stubConnector struct {
+	source model.NewsSource
+}
+
+func (c stubConnector) Source() model.NewsSource {
+	return c.source
+}
+
+func (c stubConnector) FetchSince(context.Context, *model.SyncCursor, int) (*Batch, error) {
+	return &Batch{}, nil
+}
+
+func TestRegistry(t *testing.T) {
+	tests := []struct {
+		name     string
+		register []model.NewsSource
+		wantErr  []bool
+		lookup   model.NewsSource
+		wantOK   bool
+		wantAll  []model.NewsSource
+	}{
+		{
+			name:    "empty",
+			lookup:  model.SourceJPMinkabu,
+			wantAll: []model.NewsSource{},
+		},
+		{
+			name:     "registered source",
+			register: []model.NewsSource{model.SourceJPMinkabu, model.SourceCNWind},
+			wantErr:  []bool{false, false},
+			lookup:   model.SourceCNWind,
+			wantOK:   true,
+			wantAll:  []model.NewsSource{model.SourceJPMinkabu, model.SourceCNWind},
+		},
+		{
+			name:     "duplicate registration",
+			register: []model.NewsSource{model.SourceCNWind, model.SourceJPMinkabu, model.SourceCNWind},
+			wantErr:  []bool{false, false, true},
+			lookup:   model.SourceCNWind,
+			wantOK:   true,
+			wantAll:  []model.NewsSource{model.SourceCNWind, model.SourceJPMinkabu},
+		},
+		{
+			name:     "unknown source",
+			register: []model.NewsSource{model.SourceJPMinkabu},
+			wantErr:  []bool{false},
+			lookup:   model.NewsSource("us_reuters"),
+			wantAll:  []model.NewsSource{model.SourceJPMinkabu},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry()
+			for i, source := range tt.register {
+				if err := r.Register(stubConnector{source}); (err != nil) != tt.wantErr[i] {
+					t.Errorf("Register(%s) error = %v, want error %v", source, err, tt.wantErr[i])
+				}
+			}
+
+			c, ok := r.Get(tt.lookup)
+			if ok != tt.wantOK {
+				t.Errorf("Get(%s) ok = %v, want %v", tt.lookup, ok, tt.wantOK)
+			}
+			if ok && c.Source() != tt.lookup {
+				t.Errorf("Get(%s) returned the %s connector", tt.lookup, c.Source())
+			}
+
+			all := r.All()
+			got := make([]model.NewsSource, len(all))
+			for i, c := range all {
+				got[i] = c.Source()
+			}
+			if len(got) != len(tt.wantAll) {
+				t.Fatalf("All() = %v, want %v", got, tt.wantAll)
+			}
+			for i := range got {
+				if got[i] != tt.wantAll[i] {
+					t.Fatalf("All() = %v, want %v", got, tt.wantAll)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+// listSyncRuns godoc
+// @Summary      List sync runs
+// @Description  Get paginated history of silver to gold sync runs, newest first
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        country query     string  false  "Country code (JP or CN)"
+// @Param        status  query     string  false  "Run status (running, succeeded, failed, skipped)"
+// @Param        page    query     int     false  "Page number (default: 1)"
+// @Param        limit   query     int     false  "Items per page (default: 20, max: 100)"
+// @Success      200     {object}  model.SyncRunListResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /v1/admin/sync/runs [get]
+func (h *Handler) listSyncRuns(w http.ResponseWriter, r *http.Request) {
+	filter := model.SyncRunFilter{
+		Page:  1,
+		Limit: 20,
+	}
+
+	if country := strings.ToUpper(r.URL.Query().Get("country")); country != "" {
+		c := model.CountryCode(country)
+		if c != model.CountryJP && c != model.CountryCN {
+			h.respondError(w, http.StatusBadRequest, "invalid country, must be 'JP' or 'CN'")
+			return
+		}
+		source := c.ToNewsSource()
+		filter.Source = &source
+	}
+
+	if status := r.URL.Query().Get("status"); status != "" {
+		s := model.SyncRunStatus(strings.ToLower(status))
+		if s != model.SyncRunRunning && s != model.SyncRunSucceeded && s != model.SyncRunFailed && s != model.SyncRunSkipped {
+			h.respondError(w, http.StatusBadRequest, "invalid status, must be 'running', 'succeeded', 'failed' or 'skipped'")
+			return
+		}
+		filter.Status = &s
+	}
+
+	if page := r.URL.Query().Get("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil && p > 0 {
+			filter.Page = p
+		}
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 && l <= 100 {
+			filter.Limit = l
+		}
+	}
+
+	resp, err := h.syncRunService.ListRuns(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to list sync runs", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// getSyncRun godoc
+// @Summary      Get sync run
+// @Description  Get a single sync run by ID
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Sync run ID"
+// @Success      200  {object}  model.SyncRun
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /v1/admin/sync/runs/{id} [get]
+func (h *Handler) getSyncRun(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		h.respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	run, err := h.syncRunService.GetRun(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get sync run", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if run == nil {
+		h.respondError(w, http.StatusNotFound, "sync run not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, run)
+}
+
+// triggerSync godoc
+// @Summary      Trigger sync
+// @Description  Start an immediate silver to gold sync for all sources or one country. Poll the returned runs via /v1/admin/sync/runs/{id}.
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        country query     string  false  "Country code (JP or CN); all sources if omitted"
+// @Success      202     {object}  model.SyncTriggerResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /v1/admin/sync [post]
+func (h *Handler) triggerSync(w http.ResponseWriter, r *http.Request) {
+	req := service.SyncRequest{Trigger: model.SyncTriggerManual}
+
+	if country := strings.ToUpper(r.URL.Query().Get("country")); country != "" {
+		c := model.CountryCode(country)
+		if c != model.CountryJP && c != model.CountryCN {
+			h.respondError(w, http.StatusBadRequest, "invalid country, must be 'JP' or 'CN'")
+			return
+		}
+		source := c.ToNewsSource()
+		req.Source = &source
+	}
+
+	h.executeTriggerSync(w, req)
+}
+
+// backfillSync godoc
+// @Summary      Backfill source
+// @Description  Reset one country's sync cursor to the given time and re-sync from there. Poll the returned run via /v1/admin/sync/runs/{id}.
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        country query     string  true  "Country code (JP or CN)"
+// @Param        from    query     string  true  "Re-sync silver rows updated at or after this time (RFC3339 format)"
+// @Success      202     {object}  model.SyncTriggerResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /v1/admin/sync/backfill [post]
+func (h *Handler) backfillSync(w http.ResponseWriter, r *http.Request) {
+	c := model.CountryCode(strings.ToUpper(r.URL.Query().Get("country")))
+	if c != model.CountryJP && c != model.CountryCN {
+		h.respondError(w, http.StatusBadRequest, "invalid country, must be 'JP' or 'CN'")
+		return
+	}
+	source := c.ToNewsSource()
+
+	from, err := time.Parse(time.RFC3339, r.URL.Query().Get("from"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid from, must be RFC3339")
+		return
+	}
+
+	h.executeTriggerSync(w, service.SyncRequest{
+		Source:       &source,
+		BackfillFrom: &from,
+		Trigger:      model.SyncTriggerBackfill,
+	})
+}
+
+func (h *Handler) executeTriggerSync(w http.ResponseWriter, req service.SyncRequest) {
+	runs, err := h.scheduler.Trigger(req)
+	if errors.Is(err, service.ErrSyncInProgress) {
+		h.respondError(w, http.StatusConflict, "sync already in progress")
+		return
+	}
+	if errors.Is(err, service.ErrUnknownSource) {
+		h.respondError(w, http.StatusBadRequest, "source is not configured")
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to trigger sync", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	h.respondJSON(w, http.StatusAccepted, model.SyncTriggerResponse{Runs: runs})
+}
+
+// triggerReconcile godoc
+// @Summary      Trigger reconciliation
+// @Description  Compare silver and gold over a time window and write a drift report per source. Poll the returned reports via /v1/admin/reconcile/reports/{id}.
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        country query     string  false  "Country code (JP or CN); all sources if omitted"
+// @Param        from    query     string  false  "Window start (RFC3339 format, default: 24 hours before to)"
+// @Param        to      query     string  false  "Window end (RFC3339 format, default: now)"
+// @Param        repair  query     bool    false  "Re-upsert missing and stale rows from silver and soft-delete extra rows"
+// @Success      202     {object}  model.ReconcileTriggerResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      409     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /v1/admin/reconcile [post]
+func (h *Handler) triggerReconcile(w http.ResponseWriter, r *http.Request) {
+	req := service.ReconcileRequest{To: time.Now()}
+
+	if country := strings.ToUpper(r.URL.Query().Get("country")); country != "" {
+		c := model.CountryCode(country)
+		if c != model.CountryJP && c != model.CountryCN {
+			h.respondError(w, http.StatusBadRequest, "invalid country, must be 'JP' or 'CN'")
+			return
+		}
+		source := c.ToNewsSource()
+		req.Source = &source
+	}
+
+	if to := r.URL.Query().Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid to, must be RFC3339")
+			return
+		}
+		req.To = t
+	}
+
+	req.From = req.To.Add(-24 * time.Hour)
+	if from := r.URL.Query().Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid from, must be RFC3339")
+			return
+		}
+		req.From = t
+	}
+
+	if !req.From.Before(req.To) {
+		h.respondError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	if repair := r.URL.Query().Get("repair"); repair != "" {
+		b, err := strconv.ParseBool(repair)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid repair, must be true or false")
+			return
+		}
+		req.Repair = b
+	}
+
+	reports, err := h.scheduler.Reconcile(req)
+	if errors.Is(err, service.ErrReconcileInProgress) {
+		h.respondError(w, http.StatusConflict, "reconciliation already in progress")
+		return
+	}
+	if errors.Is(err, service.ErrSyncInProgress) {
+		h.respondError(w, http.StatusConflict, "sync in progress, retry the repair later")
+		return
+	}
+	if errors.Is(err, service.ErrUnknownSource) {
+		h.respondError(w, http.StatusBadRequest, "source is not configured")
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to trigger reconciliation", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	h.respondJSON(w, http.StatusAccepted, model.ReconcileTriggerResponse{Reports: reports})
+}
+
+// listReconcileReports godoc
+// @Summary      List reconciliation reports
+// @Description  Get paginated reconciliation reports without drift items, newest first
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        country query     string  false  "Country code (JP or CN)"
+// @Param        page    query     int     false  "Page number (default: 1)"
+// @Param        limit   query     int     false  "Items per page (default: 20, max: 100)"
+// @Success      200     {object}  model.ReconcileReportListResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /v1/admin/reconcile/reports [get]
+func (h *Handler) listReconcileReports(w http.ResponseWriter, r *http.Request) {
+	filter := model.ReconcileReportFilter{
+		Page:  1,
+		Limit: 20,
+	}
+
+	if country := strings.ToUpper(r.URL.Query().Get("country")); country != "" {
+		c := model.CountryCode(country)
+		if c != model.CountryJP && c != model.CountryCN {
+			h.respondError(w, http.StatusBadRequest, "invalid country, must be 'JP' or 'CN'")
+			return
+		}
+		source := c.ToNewsSource()
+		filter.Source = &source
+	}
+
+	if page := r.URL.Query().Get("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil && p > 0 {
+			filter.Page = p
+		}
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 && l <= 100 {
+			filter.Limit = l
+		}
+	}
+
+	resp, err := h.reconcileService.ListReports(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to list reconcile reports", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// getReconcileReport godoc
+// @Summary      Get reconciliation report
+// @Description  Get a reconciliation report with its missing, extra and stale rows
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Report ID"
+// @Success      200  {object}  model.ReconcileReport
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /v1/admin/reconcile/reports/{id} [get]
+func (h *Handler) getReconcileReport(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		h.respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	report, err := h.reconcileService.GetReport(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get reconcile report", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if report == nil {
+		h.respondError(w, http.StatusNotFound, "reconcile report not found")
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, report)
+}
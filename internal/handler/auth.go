@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/onelineai/hana-news-api/internal/auth"
+	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+// apiKeyHeader carries the client API key
+const apiKeyHeader = "X-API-Key"
+
+// credentialParams are the query parameters that may carry credentials
+var credentialParams = []string{"api_key", "access_token"}
+
+// authenticate resolves the calling client from its API key header, or in JWT mode
+// also from a bearer token
+func (h *Handler) authenticate(next http.Handler) http.Handler {
+	return h.authenticateRequest(next, false)
+}
+
+// authenticateStream is authenticate for the SSE and WebSocket routes, whose
+// EventSource and browser WebSocket clients cannot set headers. They may also pass
+// the key as api_key or the token as access_token.
+func (h *Handler) authenticateStream(next http.Handler) http.Handler {
+	return h.authenticateRequest(next, true)
+}
+
+func (h *Handler) authenticateRequest(next http.Handler, queryCredentials bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.authCfg.Mode == config.AuthModeNone {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if h.authCfg.Mode == config.AuthModeJWT {
+			if token := bearerToken(r, queryCredentials); token != "" {
+				h.authenticateToken(w, r, token, next)
+				return
+			}
+		}
+
+		key := r.Header.Get(apiKeyHeader)
+		if key == "" && queryCredentials {
+			key = r.URL.Query().Get("api_key")
+		}
+		if key == "" {
+			if h.authCfg.Mode == config.AuthModeJWT {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				h.rejectCredentials(w, r, "missing API key or bearer token")
+				return
+			}
+			h.rejectCredentials(w, r, "missing API key")
+			return
+		}
+
+		client, err := h.apiClientService.Authenticate(r.Context(), key)
+		if errors.Is(err, service.ErrInvalidAPIKey) {
+			h.rejectCredentials(w, r, "invalid API key")
+			return
+		}
+		if err != nil {
+			h.logger.Error("failed to authenticate API key", "error", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithClient(r.Context(), client)))
+	})
+}
+
+// authenticateToken resolves the calling client from a bearer token
+func (h *Handler) authenticateToken(w http.ResponseWriter, r *http.Request, token string, next http.Handler) {
+	client, err := h.tokenService.Authenticate(r.Context(), token)
+	if errors.Is(err, service.ErrInvalidToken) {
+		h.logger.Debug("rejected bearer token", "error", err)
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		h.rejectCredentials(w, r, "invalid token")
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to authenticate bearer token", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(auth.WithClient(r.Context(), client)))
+}
+
+// rejectCredentials answers 401 to a request without valid credentials. The attempt
+// is charged to the caller's IP bucket first, so key guessing is rate limited.
+func (h *Handler) rejectCredentials(w http.ResponseWriter, r *http.Request, msg string) {
+	if h.applyRateLimit(w, h.rateLimitService.AllowIP(r.Context(), remoteIP(r))) {
+		h.respondError(w, http.StatusUnauthorized, msg)
+	}
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header, or if
+// allowed the access_token query parameter
+func bearerToken(r *http.Request, queryCredentials bool) string {
+	if scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	if queryCredentials {
+		return r.URL.Query().Get("access_token")
+	}
+	return ""
+}
+
+// redactingLogFormatter masks query credentials before the access log line is written
+type redactingLogFormatter struct {
+	middleware.LogFormatter
+}
+
+func (f redactingLogFormatter) NewLogEntry(r *http.Request) middleware.LogEntry {
+	if redacted, ok := redactCredentials(r.URL); ok {
+		r = r.Clone(r.Context())
+		r.URL = redacted
+		r.RequestURI = redacted.RequestURI()
+	}
+	return f.LogFormatter.NewLogEntry(r)
+}
+
+// redactCredentials returns a copy of u with credential query parameters masked.
+// Reports false if u carries none.
+func redactCredentials(u *url.URL) (*url.URL, bool) {
+	query := u.Query()
+	found := false
+	for _, param := range credentialParams {
+		if query.Has(param) {
+			query.Set(param, "REDACTED")
+			found = true
+		}
+	}
+	if !found {
+		return u, false
+	}
+	redacted := *u
+	redacted.RawQuery = query.Encode()
+	return &redacted, true
+}
+
+// requireAdmin rejects clients without the admin flag. Without authentication
+// admin endpoints are closed unless AUTH_ANONYMOUS_ADMIN opts in.
+func (h *Handler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		anonymousAdmin := h.authCfg.Mode == config.AuthModeNone && h.authCfg.AnonymousAdmin
+		if !anonymousAdmin && !auth.IsAdmin(r.Context()) {
+			h.respondError(w, http.StatusForbidden, "admin access required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// originPatterns converts the allowed origins to WebSocket host patterns
+func (h *Handler) originPatterns() []string {
+	patterns := make([]string, 0, len(h.authCfg.AllowedOrigins))
+	for _, origin := range h.authCfg.AllowedOrigins {
+		if u, err := url.Parse(origin); err == nil && u.Host != "" {
+			patterns = append(patterns, u.Host)
+		} else {
+			patterns = append(patterns, strings.TrimSuffix(origin, "/"))
+		}
+	}
+	return patterns
+}
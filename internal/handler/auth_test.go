@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+	"github.com/onelineai/hana-news-api/internal/service"
+	"github.com/onelineai/hana-news-api/internal/testdb"
+)
+
+// authTestServer is a Handler in API key mode backed by the test database, with
+// one JP and one CN article
+type authTestServer struct {
+	handler    http.Handler
+	apiClients *service.APIClientService
+	jpID, cnID string
+}
+
+func newAuthTestServer(t *testing.T) *authTestServer {
+	t.Helper()
+	pool := testdb.New(t)
+	goldRepo := repository.NewGoldRepository(pool)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	at := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+	news := []*model.TranslatedNews{
+		{Source: model.SourceJPMinkabu, SourceNewsID: "jp-1", OriginalHeadline: "見出し", TranslatedHeadline: "일본 뉴스"},
+		{Source: model.SourceCNWind, SourceNewsID: "cn-1", OriginalHeadline: "标题", TranslatedHeadline: "중국 뉴스"},
+	}
+	for _, n := range news {
+		n.PublishedAt, n.SourceUpdatedAt, n.ModelName = at, &at, "test-model"
+	}
+	if _, err := goldRepo.UpsertNews(ctx, news); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &authTestServer{apiClients: service.NewAPIClientService(goldRepo)}
+	for _, row := range []struct {
+		source model.NewsSource
+		id     *string
+	}{{model.SourceJPMinkabu, &s.jpID}, {model.SourceCNWind, &s.cnID}} {
+		if err := pool.QueryRow(ctx, `SELECT id FROM gold.translated_news WHERE source = $1`, string(row.source)).Scan(row.id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tickerService := service.NewTickerService(goldRepo, logger)
+	s.handler = New(Deps{
+		NewsService:      service.NewNewsService(goldRepo, tickerService),
+		NewsBroker:       service.NewNewsBroker(goldRepo, tickerService, config.StreamConfig{}, logger),
+		APIClientService: s.apiClients,
+		RateLimitService: service.NewRateLimitService(goldRepo, config.RateLimitConfig{}, logger),
+		UsageService:     service.NewUsageService(goldRepo, logger),
+		AuthConfig:       config.AuthConfig{Mode: config.AuthModeAPIKey},
+		Logger:           logger,
+	}).Router()
+	return s
+}
+
+// createClient registers a client licensed for the given countries and returns it with its key
+func (s *authTestServer) createClient(t *testing.T, countries ...string) *model.APIClient {
+	t.Helper()
+	client, err := s.apiClients.Create(context.Background(), model.APIClientRequest{Name: "test", Countries: countries})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// get serves a GET request with the given API key, cancelling it after timeout
+func (s *authTestServer) get(key, target string, timeout time.Duration) *httptest.ResponseRecorder {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if key != "" {
+		req.Header.Set(apiKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	s := newAuthTestServer(t)
+	client := s.createClient(t, "JP", "CN")
+	key := client.Keys[0]
+
+	other := s.createClient(t, "JP", "CN")
+	revoked := other.Keys[0]
+	if _, err := s.apiClients.RevokeKey(context.Background(), other.ID, revoked.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want int
+	}{
+		{name: "missing key", want: http.StatusUnauthorized},
+		{name: "malformed key", key: "not-a-key", want: http.StatusUnauthorized},
+		{name: "unknown key", key: "hk_" + strings.Repeat("0", 48), want: http.StatusUnauthorized},
+		{name: "revoked key", key: revoked.Key, want: http.StatusUnauthorized},
+		{name: "valid key", key: key.Key, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := s.get(tt.key, "/v1/news", 5*time.Second); rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestAPIClientSourceScope(t *testing.T) {
+	s := newAuthTestServer(t)
+	key := s.createClient(t, "JP").Keys[0].Key
+
+	listIDs := func(t *testing.T, target string) []string {
+		t.Helper()
+		rec := s.get(key, target, 5*time.Second)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s status = %d: %s", target, rec.Code, rec.Body)
+		}
+		var resp model.NewsListResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, item := range resp.Data {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+
+	t.Run("list", func(t *testing.T) {
+		if ids := listIDs(t, "/v1/news"); len(ids) != 1 || ids[0] != s.jpID {
+			t.Errorf("ids = %v, want only the JP article %s", ids, s.jpID)
+		}
+		if ids := listIDs(t, "/v1/news?country=CN"); len(ids) != 0 {
+			t.Errorf("country=CN ids = %v, want none", ids)
+		}
+	})
+
+	t.Run("detail", func(t *testing.T) {
+		if rec := s.get(key, "/v1/news/"+s.jpID, 5*time.Second); rec.Code != http.StatusOK {
+			t.Errorf("JP detail status = %d, want 200", rec.Code)
+		}
+		if rec := s.get(key, "/v1/news/"+s.cnID, 5*time.Second); rec.Code != http.StatusNotFound {
+			t.Errorf("CN detail status = %d, want 404", rec.Code)
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		// Resuming from the start replays everything the client may see, then the
+		// request is cancelled
+		for _, target := range []string{"/v1/news/stream?last_event_id=0", "/v1/news/stream?country=CN&last_event_id=0"} {
+			rec := s.get(key, target, 500*time.Millisecond)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("GET %s status = %d: %s", target, rec.Code, rec.Body)
+			}
+			body := rec.Body.String()
+			if strings.Contains(body, s.cnID) {
+				t.Errorf("GET %s streamed the CN article", target)
+			}
+			wantJP := !strings.Contains(target, "country=CN")
+			if got := strings.Contains(body, s.jpID); got != wantJP {
+				t.Errorf("GET %s streamed the JP article: %v, want %v", target, got, wantJP)
+			}
+		}
+	})
+}
@@ -0,0 +1,294 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+// listAPIClients godoc
+// @Summary      List API clients
+// @Description  Get paginated API clients without their keys, oldest first
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        page   query     int  false  "Page number (default: 1)"
+// @Param        limit  query     int  false  "Items per page (default: 20, max: 100)"
+// @Success      200    {object}  model.APIClientListResponse
+// @Failure      500    {object}  map[string]string
+// @Router       /v1/admin/clients [get]
+func (h *Handler) listAPIClients(w http.ResponseWriter, r *http.Request) {
+	filter := model.APIClientFilter{
+		Page:  1,
+		Limit: 20,
+	}
+
+	if page := r.URL.Query().Get("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil && p > 0 {
+			filter.Page = p
+		}
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 && l <= 100 {
+			filter.Limit = l
+		}
+	}
+
+	resp, err := h.apiClientService.List(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to list API clients", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// createAPIClient godoc
+// @Summary      Create API client
+// @Description  Register a client licensed for the given countries (required) and issue its first key. The key is only returned here.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        request  body      model.APIClientRequest  true  "Client settings"
+// @Success      201      {object}  model.APIClient
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /v1/admin/clients [post]
+func (h *Handler) createAPIClient(w http.ResponseWriter, r *http.Request) {
+	var req model.APIClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	client, err := h.apiClientService.Create(r.Context(), req)
+	if errors.Is(err, service.ErrInvalidAPIClient) {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to create API client", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusCreated, client)
+}
+
+// getAPIClient godoc
+// @Summary      Get API client
+// @Description  Get a single client with its keys. Only key prefixes are returned.
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Client ID"
+// @Success      200  {object}  model.APIClient
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /v1/admin/clients/{id} [get]
+func (h *Handler) getAPIClient(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.apiClientID(w, r)
+	if !ok {
+		return
+	}
+
+	client, err := h.apiClientService.Get(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get API client", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if client == nil {
+		h.respondError(w, http.StatusNotFound, "client not found")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, client)
+}
+
+// updateAPIClient godoc
+// @Summary      Update API client
+// @Description  Replace a client's name. Omitted countries, admin and disabled fields keep their current state. Disabling a client rejects all of its keys.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id       path      string                  true  "Client ID"
+// @Param        request  body      model.APIClientRequest  true  "Client settings"
+// @Success      200      {object}  model.APIClient
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /v1/admin/clients/{id} [put]
+func (h *Handler) updateAPIClient(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.apiClientID(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.APIClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	client, err := h.apiClientService.Update(r.Context(), id, req)
+	if errors.Is(err, service.ErrInvalidAPIClient) {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to update API client", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if client == nil {
+		h.respondError(w, http.StatusNotFound, "client not found")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, client)
+}
+
+// createAPIKey godoc
+// @Summary      Create API key
+// @Description  Issue a new key for a client. Set existing_key_ttl_hours to rotate: the client's other keys then expire after that grace period. The key is only returned here.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id       path      string               true  "Client ID"
+// @Param        request  body      model.APIKeyRequest  true  "Key settings"
+// @Success      201      {object}  model.APIKey
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /v1/admin/clients/{id}/keys [post]
+func (h *Handler) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.apiClientID(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.APIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	key, err := h.apiClientService.CreateKey(r.Context(), id, req)
+	if errors.Is(err, service.ErrInvalidAPIClient) {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to create API key", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if key == nil {
+		h.respondError(w, http.StatusNotFound, "client not found")
+		return
+	}
+	h.respondJSON(w, http.StatusCreated, key)
+}
+
+// revokeAPIKey godoc
+// @Summary      Revoke API key
+// @Description  Revoke a key of a client immediately
+// @Tags         admin
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id     path  string  true  "Client ID"
+// @Param        keyID  path  string  true  "Key ID"
+// @Success      204
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /v1/admin/clients/{id}/keys/{keyID} [delete]
+func (h *Handler) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.apiClientID(w, r)
+	if !ok {
+		return
+	}
+	keyID := chi.URLParam(r, "keyID")
+	if !isUUID(keyID) {
+		h.respondError(w, http.StatusNotFound, "key not found")
+		return
+	}
+
+	found, err := h.apiClientService.RevokeKey(r.Context(), id, keyID)
+	if err != nil {
+		h.logger.Error("failed to revoke API key", "error", err, "id", id, "key_id", keyID)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if !found {
+		h.respondError(w, http.StatusNotFound, "key not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apiClientID reads the client ID path parameter. Malformed IDs cannot exist and get a 404.
+func (h *Handler) apiClientID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := chi.URLParam(r, "id")
+	if !isUUID(id) {
+		h.respondError(w, http.StatusNotFound, "client not found")
+		return "", false
+	}
+	return id, true
+}
+
+// getAPIClientQuota godoc
+// @Summary      Get API client quota
+// @Description  Get a client's request counters for today and a month, with a daily breakdown. Days are counted in Asia/Seoul. Counters are written every 10 seconds per replica, so the latest requests may be missing.
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id     path      string  true   "Client ID"
+// @Param        month  query     string  false  "Month (YYYY-MM, default: current month)"
+// @Success      200    {object}  model.APIClientQuota
+// @Failure      400    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /v1/admin/clients/{id}/quota [get]
+func (h *Handler) getAPIClientQuota(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.apiClientID(w, r)
+	if !ok {
+		return
+	}
+
+	var month *time.Time
+	if m := r.URL.Query().Get("month"); m != "" {
+		t, err := time.Parse("2006-01", m)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid month, must be YYYY-MM")
+			return
+		}
+		month = &t
+	}
+
+	quota, err := h.rateLimitService.Quota(r.Context(), id, month)
+	if err != nil {
+		h.logger.Error("failed to get API client quota", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if quota == nil {
+		h.respondError(w, http.StatusNotFound, "client not found")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, quota)
+}
@@ -2,8 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
 	"log/slog"
 	"net/http"
+	"net/netip"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -14,52 +18,161 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 
 	"github.com/onelineai/hana-news-api/docs"
+	"github.com/onelineai/hana-news-api/internal/config"
 	"github.com/onelineai/hana-news-api/internal/db"
 	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/scheduler"
 	"github.com/onelineai/hana-news-api/internal/service"
 )
 
 type Handler struct {
-	newsService *service.NewsService
-	db          *db.DB
-	logger      *slog.Logger
+	newsService       *service.NewsService
+	instrumentService *service.InstrumentService
+	statsService      *service.StatsService
+	newsBroker        *service.NewsBroker
+	webhookService    *service.WebhookService
+	syncRunService    *service.SyncRunService
+	batchService      *service.BatchService
+	reconcileService  *service.ReconcileService
+	apiClientService  *service.APIClientService
+	rateLimitService  *service.RateLimitService
+	usageService      *service.UsageService
+	tokenService      *service.TokenService
+	scheduler         *scheduler.Scheduler
+	db                *db.DB
+	authCfg           config.AuthConfig
+	trustedProxies    []netip.Prefix
+	logger            *slog.Logger
 }
 
-func New(newsService *service.NewsService, db *db.DB, logger *slog.Logger) *Handler {
+// Deps holds the services and settings a Handler serves requests with
+type Deps struct {
+	NewsService       *service.NewsService
+	InstrumentService *service.InstrumentService
+	StatsService      *service.StatsService
+	NewsBroker        *service.NewsBroker
+	WebhookService    *service.WebhookService
+	SyncRunService    *service.SyncRunService
+	BatchService      *service.BatchService
+	ReconcileService  *service.ReconcileService
+	APIClientService  *service.APIClientService
+	RateLimitService  *service.RateLimitService
+	UsageService      *service.UsageService
+	TokenService      *service.TokenService
+	Scheduler         *scheduler.Scheduler
+	DB                *db.DB
+	AuthConfig        config.AuthConfig
+	// TrustedProxies are the networks whose forwarded client address headers are believed
+	TrustedProxies []netip.Prefix
+	Logger         *slog.Logger
+}
+
+func New(deps Deps) *Handler {
 	return &Handler{
-		newsService: newsService,
-		db:          db,
-		logger:      logger,
+		newsService:       deps.NewsService,
+		instrumentService: deps.InstrumentService,
+		statsService:      deps.StatsService,
+		newsBroker:        deps.NewsBroker,
+		webhookService:    deps.WebhookService,
+		syncRunService:    deps.SyncRunService,
+		batchService:      deps.BatchService,
+		reconcileService:  deps.ReconcileService,
+		apiClientService:  deps.APIClientService,
+		rateLimitService:  deps.RateLimitService,
+		usageService:      deps.UsageService,
+		tokenService:      deps.TokenService,
+		scheduler:         deps.Scheduler,
+		db:                deps.DB,
+		authCfg:           deps.AuthConfig,
+		trustedProxies:    deps.TrustedProxies,
+		logger:            deps.Logger,
 	}
 }
 
 func (h *Handler) Router() http.Handler {
 	r := chi.NewRouter()
 
-	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH", "HEAD"},
-		AllowedHeaders:   []string{"*"},
-		ExposedHeaders:   []string{"*"},
-		AllowCredentials: false,
-		MaxAge:           86400,
-	}))
+	// Without listed origins no CORS headers are sent, so browsers only allow same-origin
+	// calls; an empty AllowedOrigins would make the cors package allow every origin
+	if len(h.authCfg.AllowedOrigins) > 0 {
+		r.Use(cors.Handler(cors.Options{
+			AllowedOrigins:   h.authCfg.AllowedOrigins,
+			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH", "HEAD"},
+			AllowedHeaders:   []string{"*"},
+			ExposedHeaders:   []string{"*"},
+			AllowCredentials: false,
+			MaxAge:           86400,
+		}))
+	}
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(h.realIP)
+	r.Use(middleware.RequestLogger(redactingLogFormatter{
+		LogFormatter: &middleware.DefaultLogFormatter{Logger: log.New(os.Stdout, "", log.LstdFlags)},
+	}))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(30 * time.Second))
 
-	r.Get("/docs", func(w http.ResponseWriter, r *http.Request) {
-		http.Redirect(w, r, "/docs/index.html", http.StatusMovedPermanently)
-	})
-	r.Get("/docs/*", h.swaggerHandler())
+	// Long-lived streams manage their own deadlines
+	r.With(h.authenticateStream, h.limit, h.meter).Get("/v1/news/stream", h.streamNews)
+	r.With(h.authenticateStream, h.limit, h.meter).Get("/v1/news/ws", h.newsWebSocket)
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(30 * time.Second))
+
+		r.Get("/docs", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/docs/index.html", http.StatusMovedPermanently)
+		})
+		r.Get("/docs/*", h.swaggerHandler())
+
+		r.Get("/health", h.healthCheck)
+
+		r.Route("/v1", func(r chi.Router) {
+			r.Use(h.authenticate, h.limit, h.meter)
 
-	r.Get("/health", h.healthCheck)
+			r.Get("/news", h.listNews)
+			r.Post("/news/search", h.searchNews)
+			r.Get("/news/{id}", h.getNewsDetail)
 
-	r.Route("/v1", func(r chi.Router) {
-		r.Get("/news", h.listNews)
-		r.Get("/news/{id}", h.getNewsDetail)
+			r.Get("/instruments", h.listInstruments)
+			r.Get("/instruments/{code}/news", h.listInstrumentNews)
+
+			r.Get("/stats/tickers", h.statsTickers)
+			r.Get("/stats/tickers/{code}/histogram", h.statsTickerHistogram)
+			r.Get("/stats/topics", h.statsTopics)
+
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(h.requireAdmin)
+
+				r.Post("/sync", h.triggerSync)
+				r.Post("/sync/backfill", h.backfillSync)
+				r.Get("/sync/runs", h.listSyncRuns)
+				r.Get("/sync/runs/{id}", h.getSyncRun)
+
+				r.Post("/reconcile", h.triggerReconcile)
+				r.Get("/reconcile/reports", h.listReconcileReports)
+				r.Get("/reconcile/reports/{id}", h.getReconcileReport)
+
+				r.Post("/instruments/import", h.importInstruments)
+
+				r.Get("/webhooks", h.listWebhooks)
+				r.Post("/webhooks", h.createWebhook)
+				r.Get("/webhooks/{id}", h.getWebhook)
+				r.Put("/webhooks/{id}", h.updateWebhook)
+				r.Delete("/webhooks/{id}", h.deleteWebhook)
+				r.Post("/webhooks/{id}/replay", h.replayWebhook)
+				r.Get("/webhooks/{id}/dead-letters", h.listWebhookDeadLetters)
+
+				r.Get("/clients", h.listAPIClients)
+				r.Post("/clients", h.createAPIClient)
+				r.Get("/clients/{id}", h.getAPIClient)
+				r.Put("/clients/{id}", h.updateAPIClient)
+				r.Post("/clients/{id}/keys", h.createAPIKey)
+				r.Delete("/clients/{id}/keys/{keyID}", h.revokeAPIKey)
+				r.Get("/clients/{id}/quota", h.getAPIClientQuota)
+
+				r.Get("/usage", h.getUsageReport)
+				r.Get("/usage/articles", h.getUsageArticleReport)
+			})
+		})
 	})
 
 	return r
@@ -67,10 +180,10 @@ func (h *Handler) Router() http.Handler {
 
 // healthCheck godoc
 // @Summary      Health check
-// @Description  Check service health status
+// @Description  Check service health status. Status is "degraded" while any source's sync is failing or paused; the API itself keeps serving.
 // @Tags         health
 // @Produce      json
-// @Success      200  {object}  map[string]string
+// @Success      200  {object}  model.HealthResponse
 // @Failure      503  {object}  map[string]string
 // @Router       /health [get]
 func (h *Handler) healthCheck(w http.ResponseWriter, r *http.Request) {
@@ -79,7 +192,14 @@ func (h *Handler) healthCheck(w http.ResponseWriter, r *http.Request) {
 		h.respondError(w, http.StatusServiceUnavailable, "service unhealthy")
 		return
 	}
-	h.respondJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+
+	resp := model.HealthResponse{Status: "ok", Sources: h.batchService.Health()}
+	for _, source := range resp.Sources {
+		if source.Status != model.SourceHealthy {
+			resp.Status = "degraded"
+		}
+	}
+	h.respondJSON(w, http.StatusOK, resp)
 }
 
 // listNews godoc
@@ -88,67 +208,114 @@ func (h *Handler) healthCheck(w http.ResponseWriter, r *http.Request) {
 // @Tags         news
 // @Accept       json
 // @Produce      json
-// @Param        country query     string  false  "Country code (JP or CN)"
-// @Param        ticker  query     string  false  "Filter by ticker/stock code"
-// @Param        from    query     string  false  "Start time (RFC3339 format)"
-// @Param        to      query     string  false  "End time (RFC3339 format)"
-// @Param        page    query     int     false  "Page number (default: 1)"
-// @Param        limit   query     int     false  "Items per page (default: 20, max: 100)"
-// @Success      200     {object}  model.NewsListResponse
-// @Failure      400     {object}  map[string]string
-// @Failure      500     {object}  map[string]string
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        country         query     string    false  "Country code (JP or CN)"
+// @Param        ticker          query     []string  false  "Ticker/stock codes, repeated or comma-separated; matches any"  collectionFormat(multi)
+// @Param        topic           query     []string  false  "Topic filter, repeatable (max 20)"  collectionFormat(multi)
+// @Param        topic_match     query     string    false  "How repeated topics match: any (default) or all"
+// @Param        -topic          query     []string  false  "Exclude news with any of these topics (max 20)"  collectionFormat(multi)
+// @Param        keyword         query     []string  false  "Keyword filter, repeatable (max 20)"  collectionFormat(multi)
+// @Param        keyword_match   query     string    false  "How repeated keywords match: any (default) or all"
+// @Param        -keyword        query     []string  false  "Exclude news with any of these keywords (max 20)"  collectionFormat(multi)
+// @Param        provider        query     []string  false  "Keep news from any of these providers (max 20)"  collectionFormat(multi)
+// @Param        -provider       query     []string  false  "Exclude news from these providers (max 20)"  collectionFormat(multi)
+// @Param        q               query     string    false  "Keyword search over Korean headline and content (all terms must match, at least 2 characters each)"
+// @Param        original_q      query     string    false  "Keyword search over original Japanese/Chinese headline and content (terms of at least 2 characters)"
+// @Param        source_news_id  query     string    false  "Exact Minkabu news_id or Wind object_id"
+// @Param        sort            query     string    false  "Sort order: relevance (default with q or original_q) or latest"
+// @Param        from            query     string    false  "Start time (RFC3339 format)"
+// @Param        to              query     string    false  "End time (RFC3339 format)"
+// @Param        page            query     int       false  "Page number (default: 1)"
+// @Param        limit           query     int       false  "Items per page (default: 20, max: 100)"
+// @Param        cursor          query     string    false  "Opaque next_cursor/prev_cursor from a previous response; replaces page"
+// @Param        count           query     string    false  "Total count: exact (default with page), estimate, or none (cursor only, its default)"
+// @Success      200             {object}  model.NewsListResponse
+// @Failure      400             {object}  map[string]string
+// @Failure      500             {object}  map[string]string
 // @Router       /v1/news [get]
 func (h *Handler) listNews(w http.ResponseWriter, r *http.Request) {
-	filter := h.parseCommonFilters(r)
-
-	if country := strings.ToUpper(r.URL.Query().Get("country")); country != "" {
-		c := model.CountryCode(country)
-		if c != model.CountryJP && c != model.CountryCN {
-			h.respondError(w, http.StatusBadRequest, "invalid country, must be 'JP' or 'CN'")
-			return
-		}
-		source := c.ToNewsSource()
-		filter.Source = &source
-	}
-
-	if ticker := r.URL.Query().Get("ticker"); ticker != "" {
-		filter.Ticker = &ticker
+	filter, err := newsFilter(newsSearchRequest(r))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
 	h.executeListNews(w, r, filter)
 }
 
-func (h *Handler) parseCommonFilters(r *http.Request) model.NewsFilter {
-	filter := model.NewsFilter{
-		Page:  1,
-		Limit: 20,
+// newsSearchRequest reads the GET /v1/news query parameters. Malformed times and
+// numbers are ignored as before; other values are validated by newsFilter.
+func newsSearchRequest(r *http.Request) model.NewsSearchRequest {
+	query := r.URL.Query()
+	req := model.NewsSearchRequest{
+		Country:          query.Get("country"),
+		Tickers:          splitTickers(query["ticker"]),
+		Topics:           queryValues(r, "topic"),
+		TopicMatch:       model.ArrayMatch(query.Get("topic_match")),
+		ExcludeTopics:    queryValues(r, "-topic"),
+		Keywords:         queryValues(r, "keyword"),
+		KeywordMatch:     model.ArrayMatch(query.Get("keyword_match")),
+		ExcludeKeywords:  queryValues(r, "-keyword"),
+		Providers:        queryValues(r, "provider"),
+		ExcludeProviders: queryValues(r, "-provider"),
+		Q:                query.Get("q"),
+		OriginalQ:        query.Get("original_q"),
+		SourceNewsID:     query.Get("source_news_id"),
+		Sort:             model.NewsSort(query.Get("sort")),
+		Cursor:           query.Get("cursor"),
+		Count:            model.NewsCount(query.Get("count")),
 	}
 
-	if from := r.URL.Query().Get("from"); from != "" {
+	if from := query.Get("from"); from != "" {
 		if t, err := time.Parse(time.RFC3339, from); err == nil {
-			filter.From = &t
+			req.From = &t
 		}
 	}
 
-	if to := r.URL.Query().Get("to"); to != "" {
+	if to := query.Get("to"); to != "" {
 		if t, err := time.Parse(time.RFC3339, to); err == nil {
-			filter.To = &t
+			req.To = &t
 		}
 	}
 
-	if page := r.URL.Query().Get("page"); page != "" {
+	if page := query.Get("page"); page != "" {
 		if p, err := strconv.Atoi(page); err == nil && p > 0 {
-			filter.Page = p
+			req.Page = p
 		}
 	}
 
-	if limit := r.URL.Query().Get("limit"); limit != "" {
+	if limit := query.Get("limit"); limit != "" {
 		if l, err := strconv.Atoi(limit); err == nil && l > 0 && l <= 100 {
-			filter.Limit = l
+			req.Limit = l
 		}
 	}
 
-	return filter
+	return req
+}
+
+// queryValues returns the non-empty values of a repeated query parameter
+func queryValues(r *http.Request, key string) []string {
+	var values []string
+	for _, v := range r.URL.Query()[key] {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// splitTickers accepts both repeated and comma-separated ticker parameters
+func splitTickers(values []string) []string {
+	var tickers []string
+	for _, v := range values {
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tickers = append(tickers, t)
+			}
+		}
+	}
+	return tickers
 }
 
 func (h *Handler) executeListNews(w http.ResponseWriter, r *http.Request, filter model.NewsFilter) {
@@ -158,6 +325,7 @@ func (h *Handler) executeListNews(w http.ResponseWriter, r *http.Request, filter
 		h.respondError(w, http.StatusInternalServerError, "internal server error")
 		return
 	}
+	reportUsage(r, len(resp.Data))
 	h.respondJSON(w, http.StatusOK, resp)
 }
 
@@ -167,10 +335,13 @@ func (h *Handler) executeListNews(w http.ResponseWriter, r *http.Request, filter
 // @Tags         news
 // @Accept       json
 // @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Param        id   path      string  true  "News UUID"
 // @Success      200  {object}  model.NewsDetail
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
+// @Failure      410  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /v1/news/{id} [get]
 func (h *Handler) getNewsDetail(w http.ResponseWriter, r *http.Request) {
@@ -181,6 +352,10 @@ func (h *Handler) getNewsDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	detail, err := h.newsService.GetNewsDetail(r.Context(), id)
+	if errors.Is(err, service.ErrNewsRetracted) {
+		h.respondError(w, http.StatusGone, "news retracted")
+		return
+	}
 	if err != nil {
 		h.logger.Error("failed to get news detail", "error", err, "id", id)
 		h.respondError(w, http.StatusInternalServerError, "internal server error")
@@ -192,6 +367,7 @@ func (h *Handler) getNewsDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	reportUsage(r, 1, detail.ID)
 	h.respondJSON(w, http.StatusOK, detail)
 }
 
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+// maxImportSize caps the CSV master upload
+const maxImportSize = 32 << 20
+
+// listInstruments godoc
+// @Summary      Search instruments
+// @Description  Autocomplete on ticker, ISIN or company name in Korean, Japanese or Chinese. Ticker prefix matches rank first.
+// @Tags         instruments
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        q      query     string  false  "Ticker prefix, ISIN or part of a company name"
+// @Param        page   query     int     false  "Page number (default: 1)"
+// @Param        limit  query     int     false  "Items per page (default: 20, max: 100)"
+// @Success      200    {object}  model.InstrumentListResponse
+// @Failure      500    {object}  map[string]string
+// @Router       /v1/instruments [get]
+func (h *Handler) listInstruments(w http.ResponseWriter, r *http.Request) {
+	filter := model.InstrumentFilter{
+		Page:  1,
+		Limit: 20,
+	}
+
+	if q := strings.TrimSpace(r.URL.Query().Get("q")); q != "" {
+		filter.Query = &q
+	}
+
+	if page := r.URL.Query().Get("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil && p > 0 {
+			filter.Page = p
+		}
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 && l <= 100 {
+			filter.Limit = l
+		}
+	}
+
+	resp, err := h.instrumentService.Search(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to search instruments", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// listInstrumentNews godoc
+// @Summary      List news for an instrument
+// @Description  Same as GET /v1/news restricted to one instrument. The code may be in any supported convention (7203, 7203.T, 600519.SS, ISIN).
+// @Tags         instruments
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        code    path      string  true   "Ticker, alias or ISIN"
+// @Param        q       query     string  false  "Keyword search over Korean headline and content (terms of at least 2 characters)"
+// @Param        from    query     string  false  "Start time (RFC3339 format)"
+// @Param        to      query     string  false  "End time (RFC3339 format)"
+// @Param        page    query     int     false  "Page number (default: 1)"
+// @Param        limit   query     int     false  "Items per page (default: 20, max: 100)"
+// @Param        cursor  query     string  false  "Opaque next_cursor/prev_cursor from a previous response"
+// @Success      200     {object}  model.NewsListResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      404     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /v1/instruments/{code}/news [get]
+func (h *Handler) listInstrumentNews(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	inst, err := h.instrumentService.Get(r.Context(), code)
+	if err != nil {
+		h.logger.Error("failed to get instrument", "error", err, "code", code)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if inst == nil {
+		h.respondError(w, http.StatusNotFound, "instrument not found")
+		return
+	}
+
+	req := newsSearchRequest(r)
+	req.Tickers = []string{inst.Ticker}
+	filter, err := newsFilter(req)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.executeListNews(w, r, filter)
+}
+
+// importInstruments godoc
+// @Summary      Import instrument master
+// @Description  Upsert instruments from a CSV with a header row. Columns: ticker (required), exchange, isin, name_ko, name_ja, name_zh, aliases ('|'-separated). Blank cells keep stored values.
+// @Tags         admin
+// @Accept       text/csv
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        file  body      string  true  "CSV master"
+// @Success      200   {object}  model.InstrumentImportResult
+// @Failure      400   {object}  map[string]string
+// @Failure      500   {object}  map[string]string
+// @Router       /v1/admin/instruments/import [post]
+func (h *Handler) importInstruments(w http.ResponseWriter, r *http.Request) {
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	result, err := h.instrumentService.Import(r.Context(), body)
+	if errors.Is(err, service.ErrInvalidInstrumentCSV) {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to import instruments", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, result)
+}
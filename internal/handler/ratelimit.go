@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/auth"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+// realIP replaces RemoteAddr with the client address forwarded by a trusted proxy.
+// Unlike middleware.RealIP it ignores the headers of other peers, which could
+// otherwise pick a fresh address for every request to dodge the per-IP limit.
+func (h *Handler) realIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ip, ok := h.forwardedIP(r); ok {
+			r.RemoteAddr = ip.String()
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// forwardedIP returns the client address a trusted proxy forwarded: the right-most
+// X-Forwarded-For entry that is not itself a trusted proxy, or else X-Real-IP.
+// Reports false if the peer is not trusted or forwarded nothing usable.
+func (h *Handler) forwardedIP(r *http.Request) (netip.Addr, bool) {
+	peer, ok := parseIP(r.RemoteAddr)
+	if !ok || !h.trustedProxy(peer) {
+		return netip.Addr{}, false
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip, ok := parseIP(strings.TrimSpace(hops[i]))
+		if !ok {
+			break
+		}
+		if !h.trustedProxy(ip) {
+			return ip, true
+		}
+	}
+	if ip, ok := parseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ok {
+		return ip, true
+	}
+	return netip.Addr{}, false
+}
+
+func (h *Handler) trustedProxy(ip netip.Addr) bool {
+	for _, prefix := range h.trustedProxies {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIP parses an address with or without a port
+func parseIP(s string) (netip.Addr, bool) {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
+
+// limit applies the per-IP and, for authenticated requests, the per-client rate limit
+// in one store round trip and counts client requests towards their quota. Requests
+// with rejected credentials never get here; authenticate charges them to the IP.
+func (h *Handler) limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var clientID string
+		if client := auth.ClientFromContext(r.Context()); client != nil {
+			clientID = client.ID
+		}
+
+		if h.applyRateLimit(w, h.rateLimitService.Allow(r.Context(), remoteIP(r), clientID)) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// remoteIP returns the client address of a request without its port
+func remoteIP(r *http.Request) string {
+	if addr, ok := parseIP(r.RemoteAddr); ok {
+		return addr.String()
+	}
+	return r.RemoteAddr
+}
+
+// applyRateLimit sets the RateLimit-* headers and rejects the request with 429 if it
+// was not allowed. Reports whether to continue.
+func (h *Handler) applyRateLimit(w http.ResponseWriter, limit *service.RateLimit) bool {
+	if limit == nil {
+		return true
+	}
+
+	header := w.Header()
+	header.Set("RateLimit-Limit", strconv.Itoa(limit.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(limit.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(limit.Reset)))
+	if limit.Allowed {
+		return true
+	}
+
+	header.Set("Retry-After", strconv.Itoa(max(ceilSeconds(limit.RetryAfter), 1)))
+	h.respondError(w, http.StatusTooManyRequests, "rate limit exceeded")
+	return false
+}
+
+// ceilSeconds rounds a duration up to whole seconds
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
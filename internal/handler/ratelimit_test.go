@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestForwardedIP(t *testing.T) {
+	h := &Handler{trustedProxies: []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("130.211.0.0/22"),
+	}}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		realIP     string
+		want       string
+	}{
+		{
+			name:       "untrusted peer headers ignored",
+			remoteAddr: "203.0.113.7:52100",
+			forwarded:  []string{"198.51.100.1"},
+			realIP:     "198.51.100.2",
+		},
+		{
+			name:       "trusted peer without headers",
+			remoteAddr: "10.1.2.3:52100",
+		},
+		{
+			name:       "single hop",
+			remoteAddr: "10.1.2.3:52100",
+			forwarded:  []string{"203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "spoofed left-most entries ignored",
+			remoteAddr: "10.1.2.3:52100",
+			forwarded:  []string{"1.2.3.4, 5.6.7.8, 203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted hops skipped",
+			remoteAddr: "10.1.2.3:52100",
+			forwarded:  []string{"203.0.113.7, 130.211.0.5", "10.9.9.9"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "garbage hop stops the walk",
+			remoteAddr: "10.1.2.3:52100",
+			forwarded:  []string{"1.2.3.4, not-an-ip, 10.9.9.9"},
+			realIP:     "203.0.113.8",
+			want:       "203.0.113.8",
+		},
+		{
+			name:       "X-Real-IP from trusted peer",
+			remoteAddr: "10.1.2.3:52100",
+			realIP:     "203.0.113.8",
+			want:       "203.0.113.8",
+		},
+		{
+			name:       "IPv4-mapped peer",
+			remoteAddr: "[::ffff:10.1.2.3]:52100",
+			forwarded:  []string{"2001:db8::1"},
+			want:       "2001:db8::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/v1/news", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			ip, ok := h.forwardedIP(r)
+			got := ""
+			if ok {
+				got = ip.String()
+			}
+			if got != tt.want {
+				t.Errorf("forwardedIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
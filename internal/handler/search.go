@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+const (
+	// maxTickers caps a single watchlist query
+	maxTickers = 500
+	// maxFilterValues caps the other repeated filters
+	maxFilterValues = 20
+)
+
+// searchNews godoc
+// @Summary      Search news
+// @Description  Same as GET /v1/news with filters in the request body, for watchlists too long for a URL
+// @Tags         news
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        request  body      model.NewsSearchRequest  true  "Search filters"
+// @Success      200      {object}  model.NewsListResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /v1/news/search [post]
+func (h *Handler) searchNews(w http.ResponseWriter, r *http.Request) {
+	var req model.NewsSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	filter, err := newsFilter(req)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.executeListNews(w, r, filter)
+}
+
+// newsFilter validates a search request and converts it into a news filter
+func newsFilter(req model.NewsSearchRequest) (model.NewsFilter, error) {
+	filter := model.NewsFilter{
+		Page:  1,
+		Limit: 20,
+		From:  req.From,
+		To:    req.To,
+	}
+
+	if req.Page > 0 {
+		filter.Page = req.Page
+	}
+	if req.Limit > 0 {
+		filter.Limit = req.Limit
+	}
+
+	if country := strings.ToUpper(req.Country); country != "" {
+		c := model.CountryCode(country)
+		if c != model.CountryJP && c != model.CountryCN {
+			return filter, fmt.Errorf("invalid country, must be 'JP' or 'CN'")
+		}
+		source := c.ToNewsSource()
+		filter.Source = &source
+	}
+
+	filter.Tickers = cleanValues(req.Tickers)
+	if len(filter.Tickers) > maxTickers {
+		return filter, fmt.Errorf("too many tickers, max %d", maxTickers)
+	}
+
+	var err error
+	if filter.Providers, err = filterValues("providers", req.Providers); err != nil {
+		return filter, err
+	}
+	if filter.ExcludeProviders, err = filterValues("exclude_providers", req.ExcludeProviders); err != nil {
+		return filter, err
+	}
+	if filter.Topics, err = arrayFilter("topic", req.Topics, req.TopicMatch, req.ExcludeTopics); err != nil {
+		return filter, err
+	}
+	if filter.Keywords, err = arrayFilter("keyword", req.Keywords, req.KeywordMatch, req.ExcludeKeywords); err != nil {
+		return filter, err
+	}
+
+	if q := strings.TrimSpace(req.Q); q != "" {
+		if err := checkSearchTerms("q", q); err != nil {
+			return filter, err
+		}
+		filter.Query = &q
+	}
+
+	if originalQ := strings.TrimSpace(req.OriginalQ); originalQ != "" {
+		if err := checkSearchTerms("original_q", originalQ); err != nil {
+			return filter, err
+		}
+		filter.OriginalQuery = &originalQ
+	}
+
+	if sourceNewsID := strings.TrimSpace(req.SourceNewsID); sourceNewsID != "" {
+		filter.SourceNewsID = &sourceNewsID
+	}
+
+	switch req.Sort {
+	case "":
+	case model.SortLatest, model.SortRelevance:
+		filter.Sort = req.Sort
+	default:
+		return filter, fmt.Errorf("invalid sort, must be 'latest' or 'relevance'")
+	}
+
+	if req.Cursor != "" {
+		c, err := model.DecodeNewsCursor(req.Cursor)
+		if err != nil {
+			return filter, err
+		}
+		if filter.Sort == model.SortRelevance {
+			return filter, fmt.Errorf("cursor cannot be combined with sort=relevance")
+		}
+		filter.Cursor = c
+	}
+
+	switch req.Count {
+	case "":
+	case model.CountExact, model.CountEstimate, model.CountNone:
+		filter.Count = req.Count
+	default:
+		return filter, fmt.Errorf("invalid count, must be 'exact', 'estimate' or 'none'")
+	}
+	// Page/limit responses always carry a total
+	if filter.Count == model.CountNone && filter.Cursor == nil {
+		return filter, fmt.Errorf("count=none requires cursor")
+	}
+
+	return filter, nil
+}
+
+// checkSearchTerms rejects queries with terms too short to be searched by index
+func checkSearchTerms(name, q string) error {
+	for _, term := range model.SearchTerms(q) {
+		if utf8.RuneCountInString(term) < model.MinSearchTermLength {
+			return fmt.Errorf("%s terms must be at least %d characters", name, model.MinSearchTermLength)
+		}
+	}
+	return nil
+}
+
+// arrayFilter builds a topic/keyword filter, rejecting unknown match modes and long lists
+func arrayFilter(name string, values []string, match model.ArrayMatch, exclude []string) (model.ArrayFilter, error) {
+	f := model.ArrayFilter{Match: model.MatchAny}
+	var err error
+	if f.Values, err = filterValues(name+"s", values); err != nil {
+		return f, err
+	}
+	if f.Exclude, err = filterValues("exclude_"+name+"s", exclude); err != nil {
+		return f, err
+	}
+	switch match {
+	case "":
+	case model.MatchAny, model.MatchAll:
+		f.Match = match
+	default:
+		return f, fmt.Errorf("invalid %s_match, must be 'any' or 'all'", name)
+	}
+	return f, nil
+}
+
+// cleanValues trims values and drops empties and duplicates
+func cleanValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" && !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// filterValues cleans a repeated filter and rejects it if it exceeds maxFilterValues.
+// Dropping values would widen exclude filters without telling the caller.
+func filterValues(name string, values []string) ([]string, error) {
+	values = cleanValues(values)
+	if len(values) > maxFilterValues {
+		return nil, fmt.Errorf("too many %s, max %d", name, maxFilterValues)
+	}
+	return values, nil
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+func TestNewsFilterRejectsLongLists(t *testing.T) {
+	values := func(n int) []string {
+		out := make([]string, n)
+		for i := range out {
+			out[i] = fmt.Sprintf("v%d", i)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		req     model.NewsSearchRequest
+		wantErr string
+	}{
+		{name: "at the limit", req: model.NewsSearchRequest{Topics: values(maxFilterValues), ExcludeProviders: values(maxFilterValues)}},
+		{name: "duplicates collapse first", req: model.NewsSearchRequest{Keywords: append(values(maxFilterValues), "v0", " v1 ")}},
+		{name: "providers", req: model.NewsSearchRequest{Providers: values(21)}, wantErr: "too many providers, max 20"},
+		{name: "exclude providers", req: model.NewsSearchRequest{ExcludeProviders: values(25)}, wantErr: "too many exclude_providers, max 20"},
+		{name: "topics", req: model.NewsSearchRequest{Topics: values(21)}, wantErr: "too many topics, max 20"},
+		{name: "exclude topics", req: model.NewsSearchRequest{ExcludeTopics: values(21)}, wantErr: "too many exclude_topics, max 20"},
+		{name: "keywords", req: model.NewsSearchRequest{Keywords: values(21)}, wantErr: "too many keywords, max 20"},
+		{name: "exclude keywords", req: model.NewsSearchRequest{ExcludeKeywords: values(21)}, wantErr: "too many exclude_keywords, max 20"},
+		{name: "tickers", req: model.NewsSearchRequest{Tickers: values(maxTickers + 1)}, wantErr: "too many tickers, max 500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newsFilter(tt.req)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
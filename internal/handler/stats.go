@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+const (
+	// maxStatsWindow bounds ranking queries, which scan every row in the window
+	maxStatsWindow = 31 * 24 * time.Hour
+	// maxHourlyWindow and maxDailyWindow keep histograms under ~750 and ~370 buckets
+	maxHourlyWindow = 31 * 24 * time.Hour
+	maxDailyWindow  = 366 * 24 * time.Hour
+	// defaultStatsTimezone aligns daily buckets to Korean midnight
+	defaultStatsTimezone = "Asia/Seoul"
+)
+
+// statsTickers godoc
+// @Summary      Most mentioned tickers
+// @Description  Count live news per ticker over a time window, most mentioned first
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        country query     string  false  "Country code (JP or CN)"
+// @Param        from    query     string  false  "Window start (RFC3339, default: 24h before to)"
+// @Param        to      query     string  false  "Window end (RFC3339, default: now)"
+// @Param        limit   query     int     false  "Number of tickers (default: 20, max: 100)"
+// @Success      200     {object}  model.TickerStatsResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /v1/stats/tickers [get]
+func (h *Handler) statsTickers(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStatsFilter(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.statsService.TopTickers(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to count tickers", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// statsTopics godoc
+// @Summary      Most used topics
+// @Description  Count live news per topic over a time window, most used first
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        country query     string  false  "Country code (JP or CN)"
+// @Param        from    query     string  false  "Window start (RFC3339, default: 24h before to)"
+// @Param        to      query     string  false  "Window end (RFC3339, default: now)"
+// @Param        limit   query     int     false  "Number of topics (default: 20, max: 100)"
+// @Success      200     {object}  model.TopicStatsResponse
+// @Failure      400     {object}  map[string]string
+// @Failure      500     {object}  map[string]string
+// @Router       /v1/stats/topics [get]
+func (h *Handler) statsTopics(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStatsFilter(r)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.statsService.TopTopics(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to count topics", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// statsTickerHistogram godoc
+// @Summary      Ticker news volume histogram
+// @Description  Count live news mentioning a ticker per hour or day. Empty buckets are included with count 0.
+// @Tags         stats
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        code     path      string  true   "Ticker in any supported convention"
+// @Param        interval query     string  false  "Bucket size: hour (default) or day"
+// @Param        tz       query     string  false  "IANA time zone buckets align to, as known to the database (default: Asia/Seoul)"
+// @Param        country  query     string  false  "Country code (JP or CN)"
+// @Param        from     query     string  false  "Window start (RFC3339, default: 24h before to for hour, 30d for day)"
+// @Param        to       query     string  false  "Window end (RFC3339, default: now)"
+// @Success      200      {object}  model.TickerHistogramResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /v1/stats/tickers/{code}/histogram [get]
+func (h *Handler) statsTickerHistogram(w http.ResponseWriter, r *http.Request) {
+	filter := model.HistogramFilter{
+		Ticker:   chi.URLParam(r, "code"),
+		Interval: model.IntervalHour,
+	}
+
+	switch interval := model.StatsInterval(r.URL.Query().Get("interval")); interval {
+	case "":
+	case model.IntervalHour, model.IntervalDay:
+		filter.Interval = interval
+	default:
+		h.respondError(w, http.StatusBadRequest, "invalid interval, must be 'hour' or 'day'")
+		return
+	}
+
+	tz := r.URL.Query().Get("tz")
+	if tz == "" {
+		tz = defaultStatsTimezone
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil || tz == "Local" {
+		h.respondError(w, http.StatusBadRequest, "invalid tz, must be an IANA time zone")
+		return
+	}
+	filter.Location = loc
+
+	defaultWindow, maxWindow := 24*time.Hour, maxHourlyWindow
+	if filter.Interval == model.IntervalDay {
+		defaultWindow, maxWindow = 30*24*time.Hour, maxDailyWindow
+	}
+	if filter.From, filter.To, err = parseStatsWindow(r, defaultWindow, maxWindow); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.Source, err = parseCountry(r); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.statsService.TickerHistogram(r.Context(), filter)
+	if errors.Is(err, service.ErrUnknownTimezone) {
+		h.respondError(w, http.StatusBadRequest, "invalid tz, must be an IANA time zone")
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to build ticker histogram", "error", err, "ticker", filter.Ticker)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// parseStatsFilter reads country, window and limit for ranking endpoints
+func parseStatsFilter(r *http.Request) (model.StatsFilter, error) {
+	var filter model.StatsFilter
+	var err error
+
+	if filter.From, filter.To, err = parseStatsWindow(r, 24*time.Hour, maxStatsWindow); err != nil {
+		return filter, err
+	}
+	if filter.Source, err = parseCountry(r); err != nil {
+		return filter, err
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 && l <= 100 {
+			filter.Limit = l
+		}
+	}
+	return filter, nil
+}
+
+// parseStatsWindow reads from/to, defaulting to the window ending now
+func parseStatsWindow(r *http.Request, defaultWindow, maxWindow time.Duration) (time.Time, time.Time, error) {
+	to := time.Now()
+	if v := r.URL.Query().Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to, must be RFC3339")
+		}
+		to = t
+	}
+
+	from := to.Add(-defaultWindow)
+	if v := r.URL.Query().Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from, must be RFC3339")
+		}
+		from = t
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must be before to")
+	}
+	if to.Sub(from) > maxWindow {
+		return time.Time{}, time.Time{}, fmt.Errorf("window too large, max %d days", int(maxWindow.Hours()/24))
+	}
+	return from, to, nil
+}
+
+// parseCountry reads the optional country parameter as a news source
+func parseCountry(r *http.Request) (*model.NewsSource, error) {
+	country := strings.ToUpper(r.URL.Query().Get("country"))
+	if country == "" {
+		return nil, nil
+	}
+	c := model.CountryCode(country)
+	if c != model.CountryJP && c != model.CountryCN {
+		return nil, fmt.Errorf("invalid country, must be 'JP' or 'CN'")
+	}
+	source := c.ToNewsSource()
+	return &source, nil
+}
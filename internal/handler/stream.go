@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+const (
+	// streamReplayLimit caps events replayed on resume; beyond it the client
+	// gets a reset event and should reload with GET /v1/news
+	streamReplayLimit = 1000
+	// streamHeartbeat keeps proxies from closing idle streams
+	streamHeartbeat = 15 * time.Second
+)
+
+// streamNews godoc
+// @Summary      Stream news
+// @Description  Server-Sent Events stream of news as soon as they are synced. Each "news" event carries a NewsListItem with the gold sync sequence as its ID; reconnect with Last-Event-ID to resume. A "reset" event means too much was missed to replay and the client should reload via GET /v1/news.
+// @Tags         news
+// @Produce      text/event-stream
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        country        query     string    false  "Country code (JP or CN)"
+// @Param        ticker         query     []string  false  "Ticker/stock codes, repeated or comma-separated; matches any"  collectionFormat(multi)
+// @Param        Last-Event-ID  header    string    false  "Resume after this event ID"
+// @Param        last_event_id  query     string    false  "Same as Last-Event-ID, for clients that cannot set headers"
+// @Success      200            {object}  model.NewsListItem
+// @Failure      400            {object}  map[string]string
+// @Failure      503            {object}  map[string]string
+// @Router       /v1/news/stream [get]
+func (h *Handler) streamNews(w http.ResponseWriter, r *http.Request) {
+	filter, err := newsFilter(model.NewsSearchRequest{
+		Country: r.URL.Query().Get("country"),
+		Tickers: splitTickers(r.URL.Query()["ticker"]),
+	})
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var lastID int64
+	resume := false
+	if v := r.Header.Get("Last-Event-ID"); v != "" || r.URL.Query().Get("last_event_id") != "" {
+		if v == "" {
+			v = r.URL.Query().Get("last_event_id")
+		}
+		if lastID, err = strconv.ParseInt(v, 10, 64); err != nil || lastID < 0 {
+			h.respondError(w, http.StatusBadRequest, "invalid Last-Event-ID")
+			return
+		}
+		resume = true
+	}
+
+	ctx := r.Context()
+	sub, err := h.newsBroker.Subscribe(ctx, filter)
+	if err != nil {
+		h.respondError(w, http.StatusServiceUnavailable, "stream unavailable")
+		return
+	}
+	defer h.newsBroker.Unsubscribe(sub)
+
+	// Replay before going live; live events already replayed are skipped by sequence
+	var replay []model.NewsEvent
+	if resume {
+		if replay, err = h.newsBroker.Replay(ctx, sub, lastID, streamReplayLimit+1); err != nil {
+			h.logger.Error("failed to replay news stream", "error", err)
+			h.respondError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+	}
+
+	// Streams outlive the server write timeout
+	rc := http.NewResponseController(w)
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	sent := lastID
+	if len(replay) > streamReplayLimit {
+		writeSSE(w, "", "reset", "{}")
+	} else {
+		for _, e := range replay {
+			h.writeNewsEvent(w, e)
+			reportUsage(r, 1)
+			sent = e.Seq
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case e, ok := <-sub.Events():
+			if !ok {
+				// Dropped for lagging or server shutdown; the client resumes with Last-Event-ID
+				return
+			}
+			if e.Seq <= sent {
+				continue
+			}
+			h.writeNewsEvent(w, e)
+			reportUsage(r, 1)
+			sent = e.Seq
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeNewsEvent writes a news event with its sync sequence as the event ID
+func (h *Handler) writeNewsEvent(w http.ResponseWriter, e model.NewsEvent) {
+	data, err := json.Marshal(e.Item)
+	if err != nil {
+		h.logger.Error("failed to encode news event", "error", err, "seq", e.Seq)
+		return
+	}
+	writeSSE(w, strconv.FormatInt(e.Seq, 10), "news", string(data))
+}
+
+// writeSSE writes one Server-Sent Event; data must not contain newlines
+func writeSSE(w http.ResponseWriter, id, event, data string) {
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/onelineai/hana-news-api/internal/auth"
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+type usageKey struct{}
+
+// meter records a usage event for each request of an authenticated client once the
+// handler returns. Handlers add what they returned with reportUsage.
+func (h *Handler) meter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := auth.ClientFromContext(r.Context())
+		if client == nil || client.ID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		event := &model.UsageEvent{ClientID: client.ID, CreatedAt: time.Now()}
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), usageKey{}, event)))
+
+		event.Endpoint = r.Method + " " + chi.RouteContext(r.Context()).RoutePattern()
+		event.Status = ww.Status()
+		h.usageService.Record(*event)
+	})
+}
+
+// reportUsage adds articles returned to the usage event of a metered request. IDs are
+// only given for article details.
+func reportUsage(r *http.Request, results int, newsIDs ...string) {
+	if event, ok := r.Context().Value(usageKey{}).(*model.UsageEvent); ok {
+		event.ResultCount += results
+		event.NewsIDs = append(event.NewsIDs, newsIDs...)
+	}
+}
+
+// getUsageReport godoc
+// @Summary      Usage report
+// @Description  Total a month of client requests per client and endpoint: requests, error responses, articles returned and distinct article details opened. Months are in Asia/Seoul.
+// @Tags         admin
+// @Produce      json,text/csv
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        month      query     string  false  "Month (YYYY-MM, default: current month)"
+// @Param        client_id  query     string  false  "Only this client"
+// @Param        format     query     string  false  "json (default) or csv"
+// @Success      200        {object}  model.UsageReport
+// @Failure      400        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /v1/admin/usage [get]
+func (h *Handler) getUsageReport(w http.ResponseWriter, r *http.Request) {
+	filter, format, ok := h.usageReportParams(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.usageService.Report(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to build usage report", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if format == model.UsageReportCSV {
+		setCSVAttachment(w, fmt.Sprintf("usage-%s.csv", report.Month))
+		if err := service.WriteUsageReportCSV(w, report); err != nil {
+			h.logger.Error("failed to write usage report", "error", err)
+		}
+		return
+	}
+	h.respondJSON(w, http.StatusOK, report)
+}
+
+// getUsageArticleReport godoc
+// @Summary      Usage article report
+// @Description  List the article details each client opened in a month with view counts. Months are in Asia/Seoul.
+// @Tags         admin
+// @Produce      json,text/csv
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        month      query     string  false  "Month (YYYY-MM, default: current month)"
+// @Param        client_id  query     string  false  "Only this client"
+// @Param        format     query     string  false  "json (default) or csv"
+// @Success      200        {object}  model.UsageArticleReport
+// @Failure      400        {object}  map[string]string
+// @Failure      500        {object}  map[string]string
+// @Router       /v1/admin/usage/articles [get]
+func (h *Handler) getUsageArticleReport(w http.ResponseWriter, r *http.Request) {
+	filter, format, ok := h.usageReportParams(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.usageService.ArticleReport(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to build usage article report", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if format == model.UsageReportCSV {
+		setCSVAttachment(w, fmt.Sprintf("usage-articles-%s.csv", report.Month))
+		if err := service.WriteUsageArticleReportCSV(w, report); err != nil {
+			h.logger.Error("failed to write usage article report", "error", err)
+		}
+		return
+	}
+	h.respondJSON(w, http.StatusOK, report)
+}
+
+// usageReportParams reads the month, client_id and format parameters of usage reports
+func (h *Handler) usageReportParams(w http.ResponseWriter, r *http.Request) (model.UsageReportFilter, model.UsageReportFormat, bool) {
+	filter := model.UsageReportFilter{Month: time.Now()}
+	query := r.URL.Query()
+
+	if m := query.Get("month"); m != "" {
+		t, err := time.Parse("2006-01", m)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid month, must be YYYY-MM")
+			return filter, "", false
+		}
+		filter.Month = t
+	}
+
+	if id := query.Get("client_id"); id != "" {
+		if !isUUID(id) {
+			h.respondError(w, http.StatusBadRequest, "invalid client_id")
+			return filter, "", false
+		}
+		filter.ClientID = &id
+	}
+
+	format := model.UsageReportFormat(query.Get("format"))
+	switch format {
+	case "":
+		format = model.UsageReportJSON
+	case model.UsageReportJSON, model.UsageReportCSV:
+	default:
+		h.respondError(w, http.StatusBadRequest, "invalid format, must be 'json' or 'csv'")
+		return filter, "", false
+	}
+	return filter, format, true
+}
+
+// setCSVAttachment sets the headers of a CSV download
+func setCSVAttachment(w http.ResponseWriter, filename string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+}
@@ -0,0 +1,323 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+// listWebhooks godoc
+// @Summary      List webhooks
+// @Description  Get paginated outbound webhooks, oldest first. Secrets are never returned.
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        page   query     int  false  "Page number (default: 1)"
+// @Param        limit  query     int  false  "Items per page (default: 20, max: 100)"
+// @Success      200    {object}  model.WebhookListResponse
+// @Failure      500    {object}  map[string]string
+// @Router       /v1/admin/webhooks [get]
+func (h *Handler) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	filter := model.WebhookFilter{
+		Page:  1,
+		Limit: 20,
+	}
+
+	if page := r.URL.Query().Get("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil && p > 0 {
+			filter.Page = p
+		}
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 && l <= 100 {
+			filter.Limit = l
+		}
+	}
+
+	resp, err := h.webhookService.List(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to list webhooks", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// createWebhook godoc
+// @Summary      Create webhook
+// @Description  Register a receiver for newly synced news matching the filters. Each delivery is a POST of model.WebhookPayload signed with X-Webhook-Signature: sha256=hex(HMAC-SHA256(secret, "<X-Webhook-Timestamp>.<body>")). Non-2xx responses are retried with exponential backoff, then dead-lettered. The secret is only returned here.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        request  body      model.WebhookRequest  true  "Webhook settings"
+// @Success      201      {object}  model.Webhook
+// @Failure      400      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /v1/admin/webhooks [post]
+func (h *Handler) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var req model.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	webhook, err := h.webhookService.Create(r.Context(), req)
+	if errors.Is(err, service.ErrInvalidWebhook) {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to create webhook", "error", err)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusCreated, webhook)
+}
+
+// getWebhook godoc
+// @Summary      Get webhook
+// @Description  Get a single webhook by ID
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Webhook ID"
+// @Success      200  {object}  model.Webhook
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /v1/admin/webhooks/{id} [get]
+func (h *Handler) getWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.Get(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get webhook", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if webhook == nil {
+		h.respondError(w, http.StatusNotFound, "webhook not found")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, webhook)
+}
+
+// updateWebhook godoc
+// @Summary      Update webhook
+// @Description  Replace a webhook's URL and filters. An empty secret keeps the current one and an omitted active flag keeps the current state. A reactivated webhook skips news synced while it was inactive; use replay to catch up.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id       path      string                true  "Webhook ID"
+// @Param        request  body      model.WebhookRequest  true  "Webhook settings"
+// @Success      200      {object}  model.Webhook
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /v1/admin/webhooks/{id} [put]
+func (h *Handler) updateWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	webhook, err := h.webhookService.Update(r.Context(), id, req)
+	if errors.Is(err, service.ErrInvalidWebhook) {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to update webhook", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if webhook == nil {
+		h.respondError(w, http.StatusNotFound, "webhook not found")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, webhook)
+}
+
+// deleteWebhook godoc
+// @Summary      Delete webhook
+// @Description  Delete a webhook together with its pending deliveries and dead letters
+// @Tags         admin
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id   path  string  true  "Webhook ID"
+// @Success      204
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /v1/admin/webhooks/{id} [delete]
+func (h *Handler) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	found, err := h.webhookService.Delete(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to delete webhook", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if !found {
+		h.respondError(w, http.StatusNotFound, "webhook not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// replayWebhook godoc
+// @Summary      Replay webhook
+// @Description  Queue live news matching the webhook and published between from and to (at most 31 days) for delivery as "news.replay" events
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id       path      string                      true  "Webhook ID"
+// @Param        request  body      model.WebhookReplayRequest  true  "Publish time range (RFC3339)"
+// @Success      202      {object}  model.WebhookReplayResponse
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      500      {object}  map[string]string
+// @Router       /v1/admin/webhooks/{id}/replay [post]
+func (h *Handler) replayWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.WebhookReplayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp, err := h.webhookService.Replay(r.Context(), id, req)
+	if errors.Is(err, service.ErrInvalidWebhook) {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to replay webhook", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if resp == nil {
+		h.respondError(w, http.StatusNotFound, "webhook not found")
+		return
+	}
+	h.respondJSON(w, http.StatusAccepted, resp)
+}
+
+// listWebhookDeadLetters godoc
+// @Summary      List webhook dead letters
+// @Description  Get paginated deliveries of a webhook that failed after all retries, newest first
+// @Tags         admin
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id     path      string  true   "Webhook ID"
+// @Param        page   query     int     false  "Page number (default: 1)"
+// @Param        limit  query     int     false  "Items per page (default: 20, max: 100)"
+// @Success      200    {object}  model.WebhookDeadLetterListResponse
+// @Failure      404    {object}  map[string]string
+// @Failure      500    {object}  map[string]string
+// @Router       /v1/admin/webhooks/{id}/dead-letters [get]
+func (h *Handler) listWebhookDeadLetters(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.Get(r.Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get webhook", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if webhook == nil {
+		h.respondError(w, http.StatusNotFound, "webhook not found")
+		return
+	}
+
+	filter := model.WebhookDeadLetterFilter{
+		WebhookID: id,
+		Page:      1,
+		Limit:     20,
+	}
+
+	if page := r.URL.Query().Get("page"); page != "" {
+		if p, err := strconv.Atoi(page); err == nil && p > 0 {
+			filter.Page = p
+		}
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil && l > 0 && l <= 100 {
+			filter.Limit = l
+		}
+	}
+
+	resp, err := h.webhookService.ListDeadLetters(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to list webhook dead letters", "error", err, "id", id)
+		h.respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	h.respondJSON(w, http.StatusOK, resp)
+}
+
+// webhookID reads the webhook ID path parameter. Malformed IDs cannot exist and get a 404.
+func (h *Handler) webhookID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := chi.URLParam(r, "id")
+	if !isUUID(id) {
+		h.respondError(w, http.StatusNotFound, "webhook not found")
+		return "", false
+	}
+	return id, true
+}
+
+// isUUID reports whether s is a UUID in canonical 8-4-4-4-12 hex form
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			c := s[i]
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/service"
+)
+
+const (
+	// wsHeartbeat is the interval of server heartbeats
+	wsHeartbeat = 30 * time.Second
+	// wsWriteTimeout bounds a single write; clients that cannot take a
+	// message within it are disconnected
+	wsWriteTimeout = 10 * time.Second
+	// wsReadLimit caps the size of client messages
+	wsReadLimit = 64 << 10
+)
+
+// newsWebSocket godoc
+// @Summary      Subscribe to news over WebSocket
+// @Description  Upgrades to a WebSocket speaking a JSON protocol. Clients send {"type":"subscribe"|"unsubscribe","id":"...","tickers":[...]} and get an "ack" with the same ID and the current subscriptions, or an error such as exceeding the per-connection subscription limit. News for subscribed tickers arrive as {"type":"news","seq":...,"news":{...}}. The server sends a "heartbeat" every 30 seconds and answers client heartbeats. Slow clients whose queue overflows are closed with status 1013 and should reload via GET /v1/news before resubscribing.
+// @Tags         news
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Success      101  {object}  model.WSNewsMessage
+// @Failure      503  {object}  map[string]string
+// @Router       /v1/news/ws [get]
+func (h *Handler) newsWebSocket(w http.ResponseWriter, r *http.Request) {
+	sub, err := h.newsBroker.SubscribeTickers(r.Context())
+	if err != nil {
+		h.respondError(w, http.StatusServiceUnavailable, "stream unavailable")
+		return
+	}
+	defer h.newsBroker.Unsubscribe(sub)
+
+	// Connections outlive the server read and write timeouts
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		OriginPatterns: h.originPatterns(),
+	})
+	if err != nil {
+		// Accept already wrote the error response
+		return
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(wsReadLimit)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// The reader answers requests through replies so that all writes happen here
+	replies := make(chan any, 16)
+	go func() {
+		defer cancel()
+		h.readWebSocket(ctx, conn, sub, replies)
+	}()
+
+	heartbeat := time.NewTicker(wsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		var msg any
+		select {
+		case <-ctx.Done():
+			return
+		case msg = <-replies:
+		case <-heartbeat.C:
+			msg = model.WSHeartbeatMessage{Type: model.WSHeartbeat, Time: time.Now().UTC()}
+		case e, ok := <-sub.Events():
+			if !ok {
+				if sub.Lagged() {
+					conn.Close(websocket.StatusTryAgainLater, "slow consumer")
+				} else {
+					conn.Close(websocket.StatusGoingAway, "server shutting down")
+				}
+				return
+			}
+			msg = model.WSNewsMessage{Type: model.WSNews, Seq: e.Seq, News: e.Item}
+			reportUsage(r, 1)
+		}
+		if err := writeWebSocket(ctx, conn, msg); err != nil {
+			return
+		}
+	}
+}
+
+// readWebSocket handles client messages until the connection fails
+func (h *Handler) readWebSocket(ctx context.Context, conn *websocket.Conn, sub *service.NewsSubscription, replies chan<- any) {
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+
+		var msg model.WSClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			conn.Close(websocket.StatusUnsupportedData, "invalid JSON message")
+			return
+		}
+
+		var reply any
+		switch msg.Type {
+		case model.WSSubscribe, model.WSUnsubscribe:
+			reply = h.updateWebSocketSubscriptions(ctx, sub, msg)
+		case model.WSHeartbeat:
+			reply = model.WSHeartbeatMessage{Type: model.WSHeartbeat, Time: time.Now().UTC()}
+		default:
+			reply = model.WSAckMessage{Type: model.WSAck, ID: msg.ID, Error: "unknown message type"}
+		}
+
+		select {
+		case replies <- reply:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// updateWebSocketSubscriptions applies a subscribe or unsubscribe request and builds its ack
+func (h *Handler) updateWebSocketSubscriptions(ctx context.Context, sub *service.NewsSubscription, msg model.WSClientMessage) model.WSAckMessage {
+	ack := model.WSAckMessage{Type: model.WSAck, ID: msg.ID}
+
+	var tickers []string
+	for _, t := range msg.Tickers {
+		if t = strings.TrimSpace(t); t != "" {
+			tickers = append(tickers, t)
+		}
+	}
+	if len(tickers) == 0 {
+		ack.Error = "tickers is required"
+	} else if len(tickers) > maxTickers {
+		ack.Error = "too many tickers"
+	}
+
+	var err error
+	switch {
+	case ack.Error != "":
+		ack.Subscriptions, _ = h.newsBroker.UpdateTickers(ctx, sub, nil, nil)
+	case msg.Type == model.WSSubscribe:
+		ack.Subscriptions, err = h.newsBroker.UpdateTickers(ctx, sub, tickers, nil)
+	default:
+		ack.Subscriptions, err = h.newsBroker.UpdateTickers(ctx, sub, nil, tickers)
+	}
+	if errors.Is(err, service.ErrTooManySubscriptions) {
+		ack.Error = "subscription limit exceeded"
+	} else if err != nil {
+		h.logger.Error("failed to update websocket subscriptions", "error", err)
+		ack.Error = "internal server error"
+	}
+	if ack.Subscriptions == nil {
+		ack.Subscriptions = []string{}
+	}
+	return ack
+}
+
+// writeWebSocket writes one JSON message within wsWriteTimeout
+func writeWebSocket(ctx context.Context, conn *websocket.Conn, msg any) error {
+	ctx, cancel := context.WithTimeout(ctx, wsWriteTimeout)
+	defer cancel()
+	return wsjson.Write(ctx, conn, msg)
+}
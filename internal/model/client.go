@@ -0,0 +1,97 @@
+package model
+
+import "time"
+
+// APIClient is a B2B client allowed to call the API (gold.api_clients)
+type APIClient struct {
+	ID   string `json:"id"`
+	Name string `json:"name" example:"Hana MTS"`
+	// Countries lists the licensed news sources; empty means all
+	Countries []CountryCode `json:"countries" example:"CN"`
+	Admin     bool          `json:"admin"`
+	Disabled  bool          `json:"disabled"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	Keys      []APIKey      `json:"keys,omitempty"`
+}
+
+// Sources returns the licensed news sources; empty means all
+func (c *APIClient) Sources() []NewsSource {
+	sources := make([]NewsSource, 0, len(c.Countries))
+	for _, country := range c.Countries {
+		sources = append(sources, country.ToNewsSource())
+	}
+	return sources
+}
+
+// APIKey is an API key of a client (gold.api_client_keys)
+type APIKey struct {
+	ID     string `json:"id"`
+	Prefix string `json:"prefix" example:"hk_3f9a1c2e"`
+	// Key is the plaintext key, only returned when the key is created
+	Key        string     `json:"key,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APIClientRequest is the body of client create and update requests
+type APIClientRequest struct {
+	Name string `json:"name" example:"Hana MTS"`
+	// Countries is required on create and kept on update if omitted; an empty list
+	// is rejected
+	Countries []string `json:"countries,omitempty" example:"CN"`
+	// Admin and Disabled default to false on create and are kept on update if omitted
+	Admin    *bool `json:"admin,omitempty"`
+	Disabled *bool `json:"disabled,omitempty"`
+	// KeyExpiresAt sets the expiry of the first key; ignored on update
+	KeyExpiresAt *time.Time `json:"key_expires_at,omitempty"`
+}
+
+// APIKeyRequest is the body of a key creation (rotation) request
+type APIKeyRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// ExistingKeyTTLHours expires the client's other keys this many hours from now
+	// (0 revokes them immediately); omitted leaves them untouched
+	ExistingKeyTTLHours *int `json:"existing_key_ttl_hours,omitempty" example:"24"`
+}
+
+// APIClientFilter represents query parameters for client listing
+type APIClientFilter struct {
+	Page  int
+	Limit int
+}
+
+// APIClientListResponse is the API response for client listing
+type APIClientListResponse struct {
+	Data       []APIClient `json:"data"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+// QuotaUsage counts the requests of a client over a day or month
+type QuotaUsage struct {
+	Period   string `json:"period" example:"2026-01-29"`
+	Requests int64  `json:"requests"`
+	// RateLimited counts requests rejected with 429, which are not in Requests
+	RateLimited int64 `json:"rate_limited"`
+}
+
+// APIClientQuota is the API response for client quota counters
+type APIClientQuota struct {
+	ClientID string `json:"client_id"`
+	// Timezone days are counted in
+	Timezone string     `json:"timezone" example:"Asia/Seoul"`
+	Today    QuotaUsage `json:"today"`
+	// Month totals the requested month, broken down in Daily (days without requests omitted)
+	Month QuotaUsage   `json:"month"`
+	Daily []QuotaUsage `json:"daily"`
+}
+
+// ClientQuotaDelta is a number of requests to add to a client's daily counters
+type ClientQuotaDelta struct {
+	ClientID    string
+	Day         string // YYYY-MM-DD
+	Requests    int64
+	RateLimited int64
+}
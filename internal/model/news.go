@@ -1,6 +1,14 @@
 package model
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
 
 // NewsSource represents the source of the news (internal)
 type NewsSource string
@@ -30,6 +38,18 @@ func (c CountryCode) ToNewsSource() NewsSource {
 	}
 }
 
+// Country converts an internal news source to its country code
+func (s NewsSource) Country() CountryCode {
+	switch s {
+	case SourceJPMinkabu:
+		return CountryJP
+	case SourceCNWind:
+		return CountryCN
+	default:
+		return ""
+	}
+}
+
 // TranslatedNews represents a unified translated news record (Gold schema)
 type TranslatedNews struct {
 	ID                 string     `json:"id"`
@@ -98,7 +118,7 @@ func (n *JPMinkabuNews) ToTranslatedNews() *TranslatedNews {
 		OriginalContent:    n.OriginalStory,
 		TranslatedHeadline: n.TranslatedHeadline,
 		TranslatedContent:  n.TranslatedStory,
-		Tickers:            n.Tickers,
+		Tickers:            NormalizeTickers(n.Tickers),
 		Topics:             n.Topics,
 		Keywords:           nil,
 		Provider:           provider,
@@ -118,7 +138,7 @@ func (n *CNWindNews) ToTranslatedNews() *TranslatedNews {
 		OriginalContent:    n.OriginalContent,
 		TranslatedHeadline: n.TranslatedTitle,
 		TranslatedContent:  n.TranslatedContent,
-		Tickers:            n.WindCodes,
+		Tickers:            NormalizeTickers(n.WindCodes),
 		Topics:             n.Sections,
 		Keywords:           n.Keywords,
 		Provider:           n.Source,
@@ -137,32 +157,154 @@ type NewsListItem struct {
 	Publisher *string `json:"publisher,omitempty"`
 	Headline  string  `json:"headline"`
 	Content   *string `json:"content,omitempty"`
+	// Score and Highlight are only set for keyword searches
+	Score     *float64         `json:"score,omitempty"`
+	Highlight *SearchHighlight `json:"highlight,omitempty"`
+	// MatchedTickers lists the requested tickers the item mentions
+	MatchedTickers []string `json:"matched_tickers,omitempty"`
+	// PublishedAt keeps full precision for building cursors
+	PublishedAt time.Time `json:"-"`
+}
+
+// SearchHighlight holds HTML-escaped text with matched terms wrapped in <em> tags
+type SearchHighlight struct {
+	Headline string  `json:"headline"`
+	Snippet  *string `json:"snippet,omitempty"`
 }
 
 // NewsDetail is a unified detailed news for API response
 type NewsDetail struct {
-	ID                 string     `json:"id"`
-	Source             NewsSource `json:"source"`
-	OriginalHeadline   string     `json:"original_headline"`
-	OriginalContent    *string    `json:"original_content,omitempty"`
-	TranslatedHeadline string     `json:"translated_headline"`
-	TranslatedContent  *string    `json:"translated_content,omitempty"`
-	Tickers            []string   `json:"tickers"`
-	Topics             []string   `json:"topics,omitempty"`
-	Keywords           []string   `json:"keywords,omitempty"`
-	PublishedAt        time.Time  `json:"published_at"`
-	Provider           *string    `json:"provider,omitempty"`
-	ModelName          string     `json:"model_name"`
+	ID                 string       `json:"id"`
+	Source             NewsSource   `json:"source"`
+	OriginalHeadline   string       `json:"original_headline"`
+	OriginalContent    *string      `json:"original_content,omitempty"`
+	TranslatedHeadline string       `json:"translated_headline"`
+	TranslatedContent  *string      `json:"translated_content,omitempty"`
+	Tickers            []Instrument `json:"tickers"`
+	Topics             []string     `json:"topics,omitempty"`
+	Keywords           []string     `json:"keywords,omitempty"`
+	PublishedAt        time.Time    `json:"published_at"`
+	Provider           *string      `json:"provider,omitempty"`
+	ModelName          string       `json:"model_name"`
+	DeletedAt          *time.Time   `json:"-"`
+	// TickerCodes are the stored tickers, resolved into Tickers by the service
+	TickerCodes []string `json:"-"`
 }
 
+// NewsSort represents the ordering of a news listing
+type NewsSort string
+
+const (
+	SortLatest    NewsSort = "latest"
+	SortRelevance NewsSort = "relevance" // only meaningful with a keyword query
+)
+
 // NewsFilter represents query parameters for news listing
 type NewsFilter struct {
 	Source *NewsSource
-	Ticker *string
-	From   *time.Time
-	To     *time.Time
-	Page   int
-	Limit  int
+	// Sources limits results to the sources the caller is licensed for; nil allows all
+	Sources []NewsSource
+	// Tickers keeps news mentioning any of the codes
+	Tickers []string
+	// Query matches every whitespace-separated term in the translated headline or content
+	Query *string
+	// OriginalQuery does the same over the original Japanese/Chinese headline and content
+	OriginalQuery *string
+	// SourceNewsID matches the Minkabu news_id or Wind object_id exactly
+	SourceNewsID *string
+	Topics       ArrayFilter
+	Keywords     ArrayFilter
+	// Providers keeps news from any of the providers; ExcludeProviders drops them
+	Providers        []string
+	ExcludeProviders []string
+	Sort             NewsSort
+	From             *time.Time
+	To               *time.Time
+	Page             int
+	Limit            int
+	// Cursor switches to keyset pagination; Page is ignored when set
+	Cursor *NewsCursor
+	Count  NewsCount
+}
+
+// ArrayMatch selects how the values of an array filter are combined
+type ArrayMatch string
+
+const (
+	MatchAny ArrayMatch = "any"
+	MatchAll ArrayMatch = "all"
+)
+
+// ArrayFilter filters a text[] column such as topics or keywords
+type ArrayFilter struct {
+	Values  []string
+	Match   ArrayMatch // defaults to any
+	Exclude []string   // news containing any of these are dropped
+}
+
+// NewsCount controls how the total of a news listing is computed
+type NewsCount string
+
+const (
+	CountExact    NewsCount = "exact"    // COUNT(*), default for page/limit
+	CountEstimate NewsCount = "estimate" // planner row estimate
+	CountNone     NewsCount = "none"     // no total, cursors only and their default
+)
+
+// ErrInvalidCursor is returned for cursors that cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// NewsCursor is an opaque keyset position on (published_at, id)
+type NewsCursor struct {
+	PublishedAt time.Time `json:"t"`
+	ID          string    `json:"id"`
+	// Backward pages towards newer news (prev_cursor)
+	Backward bool `json:"b,omitempty"`
+}
+
+// Encode returns the URL-safe string form of the cursor
+func (c NewsCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeNewsCursor parses a cursor produced by Encode
+func DecodeNewsCursor(s string) (*NewsCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c NewsCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" || c.PublishedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// maxSearchTerms caps the number of ILIKE conditions a single query can generate
+const maxSearchTerms = 5
+
+// MinSearchTermLength is the shortest search term in characters. Two-character
+// terms use the bigram indexes, longer ones the trigram indexes; single characters
+// could only be found by scanning.
+const MinSearchTermLength = 2
+
+// SearchTerms splits a keyword query into distinct terms
+func SearchTerms(q string) []string {
+	var terms []string
+	seen := make(map[string]struct{})
+	for _, term := range strings.Fields(q) {
+		key := strings.ToLower(term)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		terms = append(terms, term)
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
 }
 
 // Pagination represents pagination info in response
@@ -172,8 +314,90 @@ type Pagination struct {
 	Total int `json:"total"`
 }
 
+// NewsSearchRequest is the body of POST /v1/news/search. It mirrors the
+// GET /v1/news query parameters for lists too long to fit in a URL.
+type NewsSearchRequest struct {
+	Country          string     `json:"country,omitempty" example:"JP"`
+	Tickers          []string   `json:"tickers,omitempty" example:"7203,9984"`
+	Topics           []string   `json:"topics,omitempty"`
+	TopicMatch       ArrayMatch `json:"topic_match,omitempty" enums:"any,all"`
+	ExcludeTopics    []string   `json:"exclude_topics,omitempty"`
+	Keywords         []string   `json:"keywords,omitempty"`
+	KeywordMatch     ArrayMatch `json:"keyword_match,omitempty" enums:"any,all"`
+	ExcludeKeywords  []string   `json:"exclude_keywords,omitempty"`
+	Providers        []string   `json:"providers,omitempty"`
+	ExcludeProviders []string   `json:"exclude_providers,omitempty"`
+	Q                string     `json:"q,omitempty"`
+	OriginalQ        string     `json:"original_q,omitempty"`
+	SourceNewsID     string     `json:"source_news_id,omitempty"`
+	Sort             NewsSort   `json:"sort,omitempty" enums:"latest,relevance"`
+	From             *time.Time `json:"from,omitempty"`
+	To               *time.Time `json:"to,omitempty"`
+	Page             int        `json:"page,omitempty"`
+	Limit            int        `json:"limit,omitempty"`
+	Cursor           string     `json:"cursor,omitempty"`
+	Count            NewsCount  `json:"count,omitempty" enums:"exact,estimate,none"`
+}
+
+// NewsPagination is the pagination info of a news listing. Page/limit requests
+// always get page, limit and total as in Pagination. Cursor requests get page 0,
+// and total 0 unless a count was requested.
+type NewsPagination struct {
+	Pagination
+	TotalEstimated bool    `json:"total_estimated,omitempty"`
+	NextCursor     *string `json:"next_cursor,omitempty"`
+	PrevCursor     *string `json:"prev_cursor,omitempty"`
+}
+
 // NewsListResponse is the API response for news listing
 type NewsListResponse struct {
 	Data       []NewsListItem `json:"data"`
-	Pagination Pagination     `json:"pagination"`
+	Pagination NewsPagination `json:"pagination"`
+}
+
+// ContentHash returns a stable hash of the fields copied from silver.
+// Nil and empty arrays hash the same, matching gold's '{}' defaults.
+// SourceUpdatedAt is left out so that a bare timestamp bump is not re-published;
+// upserts move it forward separately.
+func (n *TranslatedNews) ContentHash() string {
+	h := sha256.New()
+	write := func(s string) {
+		h.Write([]byte(s))
+		h.Write([]byte{0x1f})
+	}
+	writePtr := func(s *string) {
+		if s == nil {
+			h.Write([]byte{0x00})
+		} else {
+			write(*s)
+		}
+		h.Write([]byte{0x1e})
+	}
+	writeSlice := func(values []string) {
+		for _, v := range values {
+			write(v)
+		}
+		h.Write([]byte{0x1e})
+	}
+
+	write(n.OriginalHeadline)
+	writePtr(n.OriginalContent)
+	write(n.TranslatedHeadline)
+	writePtr(n.TranslatedContent)
+	writeSlice(n.Tickers)
+	writeSlice(n.Topics)
+	writeSlice(n.Keywords)
+	writePtr(n.Provider)
+	write(n.PublishedAt.UTC().Format(time.RFC3339Nano))
+	write(n.ModelName)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// NewsEvent is a news write pushed to stream subscribers
+type NewsEvent struct {
+	Seq     int64 // gold sync_seq, used as the SSE event ID
+	Source  NewsSource
+	Tickers []string
+	Item    NewsListItem
 }
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewsPaginationKeepsPaginationFields(t *testing.T) {
+	next := "abc"
+	tests := []struct {
+		name string
+		p    NewsPagination
+		want string
+	}{
+		{"empty page", NewsPagination{Pagination: Pagination{Page: 3, Limit: 20}},
+			`{"page":3,"limit":20,"total":0}`},
+		{"with cursor", NewsPagination{Pagination: Pagination{Page: 1, Limit: 20, Total: 41}, NextCursor: &next},
+			`{"page":1,"limit":20,"total":41,"next_cursor":"abc"}`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != tt.want {
+			t.Errorf("%s: %s, want %s", tt.name, b, tt.want)
+		}
+	}
+}
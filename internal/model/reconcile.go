@@ -0,0 +1,68 @@
+package model
+
+import "time"
+
+// DriftKind classifies a row that differs between silver and gold
+type DriftKind string
+
+const (
+	DriftMissing DriftKind = "missing" // in silver, not in gold
+	DriftExtra   DriftKind = "extra"   // live in gold, gone from silver
+	DriftStale   DriftKind = "stale"   // in both, but silver is newer or its content hash differs from gold
+)
+
+// ReconcileStatus represents the state of a reconciliation run
+type ReconcileStatus string
+
+const (
+	ReconcileRunning   ReconcileStatus = "running"
+	ReconcileSucceeded ReconcileStatus = "succeeded"
+	ReconcileFailed    ReconcileStatus = "failed"
+)
+
+// ReconcileReport is the drift summary of one source over one time window (gold.reconcile_reports)
+type ReconcileReport struct {
+	ID            int64           `json:"id"`
+	Source        NewsSource      `json:"source"`
+	Status        ReconcileStatus `json:"status"`
+	WindowFrom    time.Time       `json:"window_from"`
+	WindowTo      time.Time       `json:"window_to"`
+	Repair        bool            `json:"repair"`
+	StartedAt     time.Time       `json:"started_at"`
+	FinishedAt    *time.Time      `json:"finished_at,omitempty"`
+	SilverCount   int             `json:"silver_count"`
+	GoldCount     int             `json:"gold_count"`
+	MissingCount  int             `json:"missing_count"`
+	ExtraCount    int             `json:"extra_count"`
+	StaleCount    int             `json:"stale_count"`
+	RepairedCount int             `json:"repaired_count"`
+	Error         *string         `json:"error,omitempty"`
+	Drift         []DriftItem     `json:"drift,omitempty"`
+}
+
+// DriftItem is a single drifted row in a reconciliation report (gold.reconcile_drift)
+type DriftItem struct {
+	SourceNewsID    string     `json:"source_news_id"`
+	Kind            DriftKind  `json:"kind"`
+	SilverUpdatedAt *time.Time `json:"silver_updated_at,omitempty"`
+	GoldUpdatedAt   *time.Time `json:"gold_updated_at,omitempty"`
+	Repaired        bool       `json:"repaired"`
+}
+
+// ReconcileReportFilter represents query parameters for reconciliation report listing
+type ReconcileReportFilter struct {
+	Source *NewsSource
+	Page   int
+	Limit  int
+}
+
+// ReconcileReportListResponse is the API response for reconciliation report listing
+type ReconcileReportListResponse struct {
+	Data       []ReconcileReport `json:"data"`
+	Pagination Pagination        `json:"pagination"`
+}
+
+// ReconcileTriggerResponse is the API response for a started reconciliation
+type ReconcileTriggerResponse struct {
+	Reports []*ReconcileReport `json:"reports"`
+}
@@ -0,0 +1,74 @@
+package model
+
+import "time"
+
+// StatsInterval is the bucket size of a histogram
+type StatsInterval string
+
+const (
+	IntervalHour StatsInterval = "hour"
+	IntervalDay  StatsInterval = "day"
+)
+
+// StatsFilter represents query parameters for ticker/topic aggregations
+type StatsFilter struct {
+	Source *NewsSource
+	From   time.Time
+	To     time.Time
+	Limit  int
+}
+
+// TickerCount is the number of live news mentioning a ticker
+type TickerCount struct {
+	Ticker string  `json:"ticker" example:"7203.T"`
+	Count  int     `json:"count"`
+	NameKO *string `json:"name_ko,omitempty"`
+	NameJA *string `json:"name_ja,omitempty"`
+	NameZH *string `json:"name_zh,omitempty"`
+}
+
+// TopicCount is the number of live news tagged with a topic
+type TopicCount struct {
+	Topic string `json:"topic"`
+	Count int    `json:"count"`
+}
+
+// TickerStatsResponse is the API response for most mentioned tickers
+type TickerStatsResponse struct {
+	From time.Time     `json:"from"`
+	To   time.Time     `json:"to"`
+	Data []TickerCount `json:"data"`
+}
+
+// TopicStatsResponse is the API response for most used topics
+type TopicStatsResponse struct {
+	From time.Time    `json:"from"`
+	To   time.Time    `json:"to"`
+	Data []TopicCount `json:"data"`
+}
+
+// HistogramFilter represents query parameters for a ticker news volume histogram
+type HistogramFilter struct {
+	Ticker   string
+	Source   *NewsSource
+	Interval StatsInterval
+	Location *time.Location // buckets align to midnight / the hour in this zone
+	From     time.Time
+	To       time.Time
+}
+
+// HistogramBucket is the news count of one interval starting at Start
+type HistogramBucket struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+// TickerHistogramResponse is the API response for a ticker news volume histogram
+type TickerHistogramResponse struct {
+	Ticker   string            `json:"ticker" example:"7203.T"`
+	Interval StatsInterval     `json:"interval"`
+	Timezone string            `json:"timezone" example:"Asia/Seoul"`
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+	Buckets  []HistogramBucket `json:"buckets"`
+}
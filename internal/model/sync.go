@@ -0,0 +1,108 @@
+package model
+
+import "time"
+
+// SyncCursor is the keyset position of the last silver row synced for a source.
+// Rows are ordered by (updated_at, id) so rows sharing an updated_at are never skipped.
+type SyncCursor struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	ID        int64     `json:"id"`
+}
+
+// UpsertResult counts how an upsert affected gold rows
+type UpsertResult struct {
+	Inserted  int `json:"rows_inserted"`
+	Updated   int `json:"rows_updated"`
+	Unchanged int `json:"rows_unchanged"` // content hash matched, row not rewritten
+	Duplicate int `json:"rows_duplicate"` // older copy of a row repeated in the same batch, skipped
+}
+
+// Add accumulates another result
+func (r *UpsertResult) Add(other UpsertResult) {
+	r.Inserted += other.Inserted
+	r.Updated += other.Updated
+	r.Unchanged += other.Unchanged
+	r.Duplicate += other.Duplicate
+}
+
+// Written returns the number of rows actually inserted or rewritten
+func (r UpsertResult) Written() int {
+	return r.Inserted + r.Updated
+}
+
+// SyncRunStatus represents the state of a sync run
+type SyncRunStatus string
+
+const (
+	SyncRunRunning   SyncRunStatus = "running"
+	SyncRunSucceeded SyncRunStatus = "succeeded"
+	SyncRunFailed    SyncRunStatus = "failed"
+	SyncRunSkipped   SyncRunStatus = "skipped" // source paused by its circuit breaker
+)
+
+// SyncTrigger represents what started a sync run
+type SyncTrigger string
+
+const (
+	SyncTriggerScheduled SyncTrigger = "scheduled"
+	SyncTriggerManual    SyncTrigger = "manual"
+	SyncTriggerBackfill  SyncTrigger = "backfill"
+)
+
+// SyncRun is one sync of a single source (gold.sync_runs)
+type SyncRun struct {
+	ID          int64         `json:"id"`
+	Source      NewsSource    `json:"source"`
+	Status      SyncRunStatus `json:"status"`
+	Trigger     SyncTrigger   `json:"trigger"`
+	StartedAt   time.Time     `json:"started_at"`
+	FinishedAt  *time.Time    `json:"finished_at,omitempty"`
+	RowsFetched int           `json:"rows_fetched"`
+	UpsertResult
+	CursorBefore *SyncCursor `json:"cursor_before,omitempty"`
+	CursorAfter  *SyncCursor `json:"cursor_after,omitempty"`
+	Error        *string     `json:"error,omitempty"`
+}
+
+// SyncRunFilter represents query parameters for sync run listing
+type SyncRunFilter struct {
+	Source *NewsSource
+	Status *SyncRunStatus
+	Page   int
+	Limit  int
+}
+
+// SyncRunListResponse is the API response for sync run listing
+type SyncRunListResponse struct {
+	Data       []SyncRun  `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// SyncTriggerResponse is the API response for a manually triggered sync
+type SyncTriggerResponse struct {
+	Runs []*SyncRun `json:"runs"`
+}
+
+// SourceHealthStatus represents the sync health of a single source
+type SourceHealthStatus string
+
+const (
+	SourceHealthy  SourceHealthStatus = "ok"
+	SourceDegraded SourceHealthStatus = "degraded" // last run(s) failed
+	SourcePaused   SourceHealthStatus = "paused"   // circuit breaker open, syncs skipped
+)
+
+// SourceHealth is the sync health of a single source
+type SourceHealth struct {
+	Status              SourceHealthStatus `json:"status"`
+	ConsecutiveFailures int                `json:"consecutive_failures"`
+	LastError           *string            `json:"last_error,omitempty"`
+	LastSuccessAt       *time.Time         `json:"last_success_at,omitempty"`
+	PausedUntil         *time.Time         `json:"paused_until,omitempty"`
+}
+
+// HealthResponse is the API response for the health check
+type HealthResponse struct {
+	Status  string                      `json:"status"`
+	Sources map[NewsSource]SourceHealth `json:"sources,omitempty"`
+}
@@ -0,0 +1,158 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Canonical tickers are "<code>.<exchange suffix>", following Wind codes:
+// 7203.T (Tokyo), 600519.SH (Shanghai), 000001.SZ (Shenzhen), 830799.BJ (Beijing)
+const (
+	SuffixTokyo    = "T"
+	SuffixShanghai = "SH"
+	SuffixShenzhen = "SZ"
+	SuffixBeijing  = "BJ"
+	SuffixHongKong = "HK"
+)
+
+// suffixAliases maps exchange suffixes used by other vendors to the canonical one
+var suffixAliases = map[string]string{
+	"T":   SuffixTokyo,
+	"JP":  SuffixTokyo,
+	"TYO": SuffixTokyo,
+	"TSE": SuffixTokyo,
+	"SH":  SuffixShanghai,
+	"SS":  SuffixShanghai,
+	"SHG": SuffixShanghai,
+	"SZ":  SuffixShenzhen,
+	"SHE": SuffixShenzhen,
+	"BJ":  SuffixBeijing,
+	"HK":  SuffixHongKong,
+}
+
+// exchangeNames maps canonical suffixes to exchange names
+var exchangeNames = map[string]string{
+	SuffixTokyo:    "TSE",
+	SuffixShanghai: "SSE",
+	SuffixShenzhen: "SZSE",
+	SuffixBeijing:  "BSE",
+	SuffixHongKong: "HKEX",
+}
+
+// NormalizeTicker converts a ticker in any supported convention to its canonical form.
+// Codes it cannot classify are returned upper-cased but otherwise unchanged.
+// Keep in sync with gold.normalize_ticker (migrations/013_add_ticker_normalization.sql);
+// TestNormalizeTickerMatchesSQL compares the two.
+func NormalizeTicker(raw string) string {
+	s := strings.ToUpper(strings.Join(strings.Fields(raw), ""))
+	if s == "" {
+		return ""
+	}
+
+	if i := strings.LastIndexByte(s, '.'); i > 0 {
+		if suffix, ok := suffixAliases[s[i+1:]]; ok {
+			return s[:i] + "." + suffix
+		}
+		return s
+	}
+
+	if suffix := inferSuffix(s); suffix != "" {
+		return s + "." + suffix
+	}
+	return s
+}
+
+// inferSuffix guesses the exchange of a bare code from its shape
+func inferSuffix(code string) string {
+	switch {
+	case len(code) == 6 && isDigits(code):
+		switch code[0] {
+		case '6', '9':
+			return SuffixShanghai
+		case '0', '2', '3':
+			return SuffixShenzhen
+		case '4', '8':
+			return SuffixBeijing
+		}
+	case len(code) == 4 && isDigits(code[:3]) && isAlnum(code[3]):
+		// TSE codes: 7203, and the newer alphanumeric form 130A
+		return SuffixTokyo
+	}
+	return ""
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z')
+}
+
+// NormalizeTickers normalizes a ticker list, dropping empties and duplicates
+func NormalizeTickers(raw []string) []string {
+	if raw == nil {
+		return nil
+	}
+	out := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, r := range raw {
+		t := NormalizeTicker(r)
+		if t == "" {
+			continue
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		out = append(out, t)
+	}
+	return out
+}
+
+// TickerExchange returns the exchange name of a canonical ticker, or "" if unknown
+func TickerExchange(ticker string) string {
+	if i := strings.LastIndexByte(ticker, '.'); i > 0 {
+		return exchangeNames[ticker[i+1:]]
+	}
+	return ""
+}
+
+// Instrument is an entry of the ticker mapping table (gold.instruments)
+type Instrument struct {
+	Ticker   string   `json:"ticker" example:"7203.T"`
+	Exchange string   `json:"exchange,omitempty" example:"TSE"`
+	ISIN     *string  `json:"isin,omitempty" example:"JP3633400001"`
+	NameKO   *string  `json:"name_ko,omitempty" example:"도요타자동차"`
+	NameJA   *string  `json:"name_ja,omitempty" example:"トヨタ自動車"`
+	NameZH   *string  `json:"name_zh,omitempty" example:"丰田汽车"`
+	Aliases  []string `json:"aliases,omitempty"`
+	// FirstSeenAt and LastSeenAt span the news mentioning the ticker; nil if never seen
+	FirstSeenAt *time.Time `json:"first_seen_at,omitempty"`
+	LastSeenAt  *time.Time `json:"last_seen_at,omitempty"`
+	UpdatedAt   time.Time  `json:"-"`
+}
+
+// InstrumentFilter represents query parameters for instrument search
+type InstrumentFilter struct {
+	// Query matches a ticker prefix, ISIN, alias or part of a company name
+	Query *string
+	Page  int
+	Limit int
+}
+
+// InstrumentListResponse is the API response for instrument search
+type InstrumentListResponse struct {
+	Data       []Instrument `json:"data"`
+	Pagination Pagination   `json:"pagination"`
+}
+
+// InstrumentImportResult is the outcome of a CSV master import
+type InstrumentImportResult struct {
+	Rows int `json:"rows"`
+}
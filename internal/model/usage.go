@@ -0,0 +1,69 @@
+package model
+
+import "time"
+
+// UsageEvent is one metered request of a client (gold.usage_events)
+type UsageEvent struct {
+	ClientID string
+	// Endpoint is the method and route pattern, e.g. "GET /v1/news/{id}"
+	Endpoint string
+	Status   int
+	// ResultCount is the number of articles returned
+	ResultCount int
+	// NewsIDs lists the articles whose detail was returned
+	NewsIDs   []string
+	CreatedAt time.Time
+}
+
+// UsageReportFormat is the output format of usage reports
+type UsageReportFormat string
+
+const (
+	UsageReportJSON UsageReportFormat = "json"
+	UsageReportCSV  UsageReportFormat = "csv"
+)
+
+// UsageReportFilter selects the events of a usage report
+type UsageReportFilter struct {
+	// Month is any time within the reported month
+	Month    time.Time
+	ClientID *string
+}
+
+// UsageReport totals the requests of clients per endpoint over a month
+type UsageReport struct {
+	Month    string           `json:"month" example:"2026-01"`
+	Timezone string           `json:"timezone" example:"Asia/Seoul"`
+	Data     []UsageReportRow `json:"data"`
+}
+
+// UsageReportRow totals the requests of one client to one endpoint
+type UsageReportRow struct {
+	ClientID   string `json:"client_id"`
+	ClientName string `json:"client_name" example:"Hana MTS"`
+	Endpoint   string `json:"endpoint" example:"GET /v1/news"`
+	Requests   int64  `json:"requests"`
+	// Errors counts requests answered with a 4xx or 5xx status
+	Errors int64 `json:"errors"`
+	// Articles is the number of articles returned
+	Articles int64 `json:"articles"`
+	// UniqueArticles is the number of distinct articles whose detail was returned
+	UniqueArticles int64 `json:"unique_articles"`
+}
+
+// UsageArticleReport lists the article details clients opened over a month
+type UsageArticleReport struct {
+	Month    string            `json:"month" example:"2026-01"`
+	Timezone string            `json:"timezone" example:"Asia/Seoul"`
+	Data     []UsageArticleRow `json:"data"`
+}
+
+// UsageArticleRow counts the detail views of one article by one client
+type UsageArticleRow struct {
+	ClientID      string    `json:"client_id"`
+	ClientName    string    `json:"client_name" example:"Hana MTS"`
+	NewsID        string    `json:"news_id"`
+	Views         int64     `json:"views"`
+	FirstViewedAt time.Time `json:"first_viewed_at"`
+	LastViewedAt  time.Time `json:"last_viewed_at"`
+}
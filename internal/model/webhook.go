@@ -0,0 +1,125 @@
+package model
+
+import "time"
+
+// WebhookEvent is the kind of a webhook delivery
+type WebhookEvent string
+
+const (
+	WebhookEventSynced WebhookEvent = "news.synced" // news inserted or updated by a sync
+	WebhookEventReplay WebhookEvent = "news.replay" // re-sent by an admin replay
+)
+
+// Webhook is an outbound receiver of newly synced news (gold.webhook_subscriptions)
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url" example:"https://example.com/hooks/news"`
+	// Secret is the HMAC-SHA256 signing key, only returned when the webhook is created
+	Secret      string       `json:"secret,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Country     *CountryCode `json:"country,omitempty" example:"JP"`
+	Tickers     []string     `json:"tickers"`
+	Topics      []string     `json:"topics"`
+	Active      bool         `json:"active"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// NewsFilter returns the filter selecting news for the webhook
+func (w Webhook) NewsFilter() NewsFilter {
+	var filter NewsFilter
+	if w.Country != nil {
+		source := w.Country.ToNewsSource()
+		filter.Source = &source
+	}
+	filter.Tickers = w.Tickers
+	filter.Topics = ArrayFilter{Values: w.Topics, Match: MatchAny}
+	return filter
+}
+
+// WebhookRequest is the body of webhook create and update requests.
+// Empty filters match all news.
+type WebhookRequest struct {
+	URL string `json:"url" example:"https://example.com/hooks/news"`
+	// Secret is generated on create if empty and kept on update if empty
+	Secret      string   `json:"secret,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Country     string   `json:"country,omitempty" example:"JP"`
+	Tickers     []string `json:"tickers,omitempty" example:"7203.T"`
+	Topics      []string `json:"topics,omitempty"`
+	// Active defaults to true on create and is kept on update if omitted
+	Active *bool `json:"active,omitempty"`
+}
+
+// WebhookFilter represents query parameters for webhook listing
+type WebhookFilter struct {
+	Page  int
+	Limit int
+}
+
+// WebhookListResponse is the API response for webhook listing
+type WebhookListResponse struct {
+	Data       []Webhook  `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// WebhookReplayRequest selects news to re-send by publish time (inclusive)
+type WebhookReplayRequest struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// WebhookReplayResponse is the API response for a webhook replay
+type WebhookReplayResponse struct {
+	Queued int `json:"queued"`
+}
+
+// WebhookPayload is the JSON body posted to webhook receivers
+type WebhookPayload struct {
+	// ID identifies the delivery and stays the same across retries
+	ID        string       `json:"id" example:"1024"`
+	Event     WebhookEvent `json:"event" example:"news.synced"`
+	WebhookID string       `json:"webhook_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	// Seq is the gold sync sequence of the news; later writes of the same news have higher values
+	Seq  int64        `json:"seq" example:"120345"`
+	News NewsListItem `json:"news"`
+}
+
+// WebhookDelivery is a claimed delivery attempt
+type WebhookDelivery struct {
+	ID        int64
+	Event     WebhookEvent
+	Attempts  int // including the current one
+	CreatedAt time.Time
+	Webhook   Webhook
+	// News is nil if the news was retracted since it was queued, or rewritten and
+	// waiting to be queued again
+	News *NewsEvent
+}
+
+// WebhookDeadLetter is a delivery that failed after all retries (gold.webhook_dead_letters)
+type WebhookDeadLetter struct {
+	ID         int64        `json:"id"`
+	WebhookID  string       `json:"webhook_id"`
+	NewsID     string       `json:"news_id"`
+	Event      WebhookEvent `json:"event"`
+	Attempts   int          `json:"attempts"`
+	LastStatus *int         `json:"last_status,omitempty"`
+	LastError  *string      `json:"last_error,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	FailedAt   time.Time    `json:"failed_at"`
+}
+
+// WebhookDeadLetterFilter represents query parameters for dead letter listing
+type WebhookDeadLetterFilter struct {
+	WebhookID string
+	Page      int
+	Limit     int
+}
+
+// WebhookDeadLetterListResponse is the API response for dead letter listing
+type WebhookDeadLetterListResponse struct {
+	Data       []WebhookDeadLetter `json:"data"`
+	Pagination Pagination          `json:"pagination"`
+}
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// WebSocket message types of /v1/news/ws
+const (
+	WSSubscribe   = "subscribe"
+	WSUnsubscribe = "unsubscribe"
+	WSAck         = "ack"
+	WSNews        = "news"
+	WSHeartbeat   = "heartbeat"
+)
+
+// WSClientMessage is a message sent by a WebSocket client.
+// subscribe and unsubscribe are answered with an ack carrying the same ID;
+// heartbeat is answered with a heartbeat.
+type WSClientMessage struct {
+	Type    string   `json:"type" example:"subscribe"`
+	ID      string   `json:"id,omitempty" example:"1"`
+	Tickers []string `json:"tickers,omitempty" example:"7203.T,600519.SH"`
+}
+
+// WSAckMessage answers a subscribe or unsubscribe request with the resulting subscriptions
+type WSAckMessage struct {
+	Type          string   `json:"type" example:"ack"`
+	ID            string   `json:"id,omitempty" example:"1"`
+	Subscriptions []string `json:"subscriptions"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// WSNewsMessage delivers a news item matching the subscriptions
+type WSNewsMessage struct {
+	Type string       `json:"type" example:"news"`
+	Seq  int64        `json:"seq" example:"1024"`
+	News NewsListItem `json:"news"`
+}
+
+// WSHeartbeatMessage is sent periodically and in reply to client heartbeats
+type WSHeartbeatMessage struct {
+	Type string    `json:"type" example:"heartbeat"`
+	Time time.Time `json:"time"`
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+const apiClientColumns = `id, name, sources, admin, disabled, created_at, updated_at`
+
+// CreateAPIClient stores a new client
+func (r *GoldRepository) CreateAPIClient(ctx context.Context, c *model.APIClient) error {
+	return r.pool.QueryRow(ctx, `
+		INSERT INTO gold.api_clients (name, sources, admin, disabled)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`, c.Name, clientSources(c), c.Admin, c.Disabled).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+}
+
+// UpdateAPIClient replaces a client's settings. Returns false if the client does not exist.
+func (r *GoldRepository) UpdateAPIClient(ctx context.Context, c *model.APIClient) (bool, error) {
+	err := r.pool.QueryRow(ctx, `
+		UPDATE gold.api_clients
+		SET name = $2, sources = $3, admin = $4, disabled = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`, c.ID, c.Name, clientSources(c), c.Admin, c.Disabled).Scan(&c.CreatedAt, &c.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetAPIClient returns a client with its keys, or nil if it does not exist
+func (r *GoldRepository) GetAPIClient(ctx context.Context, id string) (*model.APIClient, error) {
+	row := r.pool.QueryRow(ctx, fmt.Sprintf(`SELECT %s FROM gold.api_clients WHERE id = $1`, apiClientColumns), id)
+	c, err := scanAPIClient(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, prefix, expires_at, revoked_at, last_used_at, created_at
+		FROM gold.api_client_keys
+		WHERE client_id = $1
+		ORDER BY created_at, id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	c.Keys, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.APIKey, error) {
+		var k model.APIKey
+		err := row.Scan(&k.ID, &k.Prefix, &k.ExpiresAt, &k.RevokedAt, &k.LastUsedAt, &k.CreatedAt)
+		return k, err
+	})
+	return c, err
+}
+
+// ListAPIClients returns paginated clients without their keys, oldest first
+func (r *GoldRepository) ListAPIClients(ctx context.Context, filter model.APIClientFilter) ([]model.APIClient, int, error) {
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM gold.api_clients`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM gold.api_clients
+		ORDER BY created_at, id
+		LIMIT $1 OFFSET $2
+	`, apiClientColumns), filter.Limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	clients := []model.APIClient{}
+	for rows.Next() {
+		c, err := scanAPIClient(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		clients = append(clients, *c)
+	}
+	return clients, total, rows.Err()
+}
+
+// CreateAPIKey stores a key hash for a client. If existingExpireAt is set, the client's
+// other live keys expire no later than that time. Returns false if the client does not exist.
+func (r *GoldRepository) CreateAPIKey(ctx context.Context, clientID string, key *model.APIKey, keyHash string, existingExpireAt *time.Time) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if existingExpireAt != nil {
+		if _, err := tx.Exec(ctx, `
+			UPDATE gold.api_client_keys
+			SET expires_at = LEAST(COALESCE(expires_at, $2), $2)
+			WHERE client_id = $1 AND revoked_at IS NULL
+		`, clientID, *existingExpireAt); err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO gold.api_client_keys (client_id, prefix, key_hash, expires_at)
+		SELECT id, $2, $3, $4::timestamptz FROM gold.api_clients WHERE id = $1
+		RETURNING id, created_at
+	`, clientID, key.Prefix, keyHash, key.ExpiresAt).Scan(&key.ID, &key.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// RevokeAPIKey revokes a key of a client. Returns false if no such live key exists.
+func (r *GoldRepository) RevokeAPIKey(ctx context.Context, clientID, keyID string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE gold.api_client_keys
+		SET revoked_at = NOW()
+		WHERE id = $1 AND client_id = $2 AND revoked_at IS NULL
+	`, keyID, clientID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// AuthenticateAPIKey returns the enabled client owning a live key with the given hash
+// and the key's expiry, recording the key as used. Returns nil if there is none.
+func (r *GoldRepository) AuthenticateAPIKey(ctx context.Context, keyHash string) (*model.APIClient, *time.Time, error) {
+	var expiresAt *time.Time
+	var c model.APIClient
+	var sources []string
+	err := r.pool.QueryRow(ctx, `
+		WITH k AS (
+			UPDATE gold.api_client_keys
+			SET last_used_at = NOW()
+			WHERE key_hash = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+			RETURNING client_id, expires_at
+		)
+		SELECT c.id, c.name, c.sources, c.admin, c.disabled, c.created_at, c.updated_at, k.expires_at
+		FROM k
+		JOIN gold.api_clients c ON c.id = k.client_id
+		WHERE NOT c.disabled
+	`, keyHash).Scan(&c.ID, &c.Name, &sources, &c.Admin, &c.Disabled, &c.CreatedAt, &c.UpdatedAt, &expiresAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	c.Countries = sourceCountries(sources)
+	return &c, expiresAt, nil
+}
+
+func scanAPIClient(row pgx.Row) (*model.APIClient, error) {
+	var c model.APIClient
+	var sources []string
+	if err := row.Scan(&c.ID, &c.Name, &sources, &c.Admin, &c.Disabled, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	c.Countries = sourceCountries(sources)
+	return &c, nil
+}
+
+// clientSources returns the sources column value of a client
+func clientSources(c *model.APIClient) []string {
+	sources := make([]string, 0, len(c.Countries))
+	for _, s := range c.Sources() {
+		sources = append(sources, string(s))
+	}
+	return sources
+}
+
+func sourceCountries(sources []string) []model.CountryCode {
+	countries := make([]model.CountryCode, 0, len(sources))
+	for _, s := range sources {
+		countries = append(countries, model.NewsSource(s).Country())
+	}
+	return countries
+}
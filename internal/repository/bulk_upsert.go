@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// BulkUpsertNewsWithCursor is UpsertNewsWithCursor for large backfills. Rows are COPYed
+// into a transaction-scoped staging table and merged with one INSERT ... SELECT, which
+// is much faster than one statement per row.
+func (r *GoldRepository) BulkUpsertNewsWithCursor(ctx context.Context, source model.NewsSource, news []*model.TranslatedNews, cursor model.SyncCursor, runCount int) (model.UpsertResult, error) {
+	return r.withSyncCursor(ctx, source, cursor, runCount, func(tx pgx.Tx) (model.UpsertResult, error) {
+		return bulkUpsertNews(ctx, tx, news)
+	})
+}
+
+func bulkUpsertNews(ctx context.Context, tx pgx.Tx, news []*model.TranslatedNews) (model.UpsertResult, error) {
+	var result model.UpsertResult
+	if len(news) == 0 {
+		return result, nil
+	}
+
+	_, err := tx.Exec(ctx, `
+		CREATE TEMP TABLE news_staging (
+			source              VARCHAR(20),
+			source_news_id      VARCHAR(255),
+			original_headline   TEXT,
+			original_content    TEXT,
+			translated_headline TEXT,
+			translated_content  TEXT,
+			tickers             TEXT[],
+			topics              TEXT[],
+			keywords            TEXT[],
+			provider            VARCHAR(100),
+			published_at        TIMESTAMPTZ,
+			model_name          VARCHAR(100),
+			source_created_at   TIMESTAMPTZ,
+			source_updated_at   TIMESTAMPTZ,
+			content_hash        VARCHAR(64)
+		) ON COMMIT DROP
+	`)
+	if err != nil {
+		return result, err
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"news_staging"},
+		[]string{
+			"source", "source_news_id", "original_headline", "original_content",
+			"translated_headline", "translated_content", "tickers", "topics", "keywords",
+			"provider", "published_at", "model_name", "source_created_at", "source_updated_at",
+			"content_hash",
+		},
+		pgx.CopyFromSlice(len(news), func(i int) ([]any, error) {
+			n := news[i]
+			return []any{
+				string(n.Source), n.SourceNewsID, n.OriginalHeadline, n.OriginalContent,
+				n.TranslatedHeadline, n.TranslatedContent, n.Tickers, n.Topics, n.Keywords,
+				n.Provider, n.PublishedAt, n.ModelName, n.SourceCreatedAt, n.SourceUpdatedAt,
+				n.ContentHash(),
+			}, nil
+		}),
+	)
+	if err != nil {
+		return result, err
+	}
+
+	// DISTINCT ON keeps the newest copy of a row that appears twice in one batch;
+	// ON CONFLICT cannot touch the same target row twice in one statement.
+	rows, err := tx.Query(ctx, `
+		INSERT INTO gold.translated_news
+			(id, source, source_news_id, original_headline, original_content,
+			 translated_headline, translated_content, tickers, topics, keywords,
+			 provider, published_at, model_name, source_created_at, source_updated_at,
+			 content_hash, synced_at)
+		SELECT DISTINCT ON (source, source_news_id)
+			gen_random_uuid(), source, source_news_id, original_headline, original_content,
+			translated_headline, translated_content, tickers, topics, keywords,
+			provider, published_at, model_name, source_created_at, source_updated_at,
+			content_hash, NOW()
+		FROM news_staging
+		ORDER BY source, source_news_id, source_updated_at DESC
+	`+newsUpsertConflict)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var inserted bool
+		if err := rows.Scan(&inserted); err != nil {
+			return result, err
+		}
+		if inserted {
+			result.Inserted++
+		} else {
+			result.Updated++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	// Older copies dropped by DISTINCT ON were never compared with gold
+	distinct := make(map[newsKey]struct{}, len(news))
+	for _, n := range news {
+		distinct[newsKey{n.Source, n.SourceNewsID}] = struct{}{}
+	}
+	result.Duplicate = len(news) - len(distinct)
+	result.Unchanged = len(distinct) - result.Written()
+
+	batch := &pgx.Batch{}
+	queueRefreshSourceUpdatedAt(batch, news)
+	queueTouchInstruments(batch, news)
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/testdb"
+)
+
+// testNews returns count gold rows of one source numbered from first
+func testNews(first, count int, updatedAt time.Time) []*model.TranslatedNews {
+	content := "本文 본문"
+	news := make([]*model.TranslatedNews, count)
+	for i := range news {
+		news[i] = &model.TranslatedNews{
+			Source:             model.SourceJPMinkabu,
+			SourceNewsID:       fmt.Sprintf("jp-%d", first+i),
+			OriginalHeadline:   "見出し",
+			OriginalContent:    &content,
+			TranslatedHeadline: "헤드라인",
+			TranslatedContent:  &content,
+			Tickers:            []string{"7203"},
+			Topics:             []string{},
+			Keywords:           []string{},
+			PublishedAt:        updatedAt,
+			ModelName:          "test-model",
+			SourceUpdatedAt:    &updatedAt,
+		}
+	}
+	return news
+}
+
+func TestBulkUpsertCountsDuplicates(t *testing.T) {
+	pool := testdb.New(t)
+	gold := NewGoldRepository(pool)
+	ctx := context.Background()
+	at := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+	cursor := model.SyncCursor{UpdatedAt: at, ID: 1}
+
+	if _, err := gold.BulkUpsertNewsWithCursor(ctx, model.SourceJPMinkabu, testNews(1, 3, at), cursor, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// jp-1 is unchanged, jp-2 appears twice with a newer edit, jp-4 is new
+	later := at.Add(time.Minute)
+	edited := testNews(2, 1, later)
+	edited[0].TranslatedHeadline = "수정된 헤드라인"
+	batch := append(testNews(1, 2, at), edited[0])
+	batch = append(batch, testNews(4, 1, at)...)
+
+	result, err := gold.BulkUpsertNewsWithCursor(ctx, model.SourceJPMinkabu, batch, cursor, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := model.UpsertResult{Inserted: 1, Updated: 1, Unchanged: 1, Duplicate: 1}
+	if result != want {
+		t.Fatalf("result = %+v, want %+v", result, want)
+	}
+
+	var headline string
+	err = pool.QueryRow(ctx, `
+		SELECT translated_headline FROM gold.translated_news WHERE source_news_id = 'jp-2'
+	`).Scan(&headline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headline != "수정된 헤드라인" {
+		t.Errorf("jp-2 headline = %q, want the newest copy", headline)
+	}
+}
+
+// BenchmarkUpsertNewsWithCursor compares the per-row and COPY upserts on fresh rows.
+// Run with TEST_DATABASE_URL set, e.g. go test -run x -bench UpsertNews ./internal/repository
+func BenchmarkUpsertNewsWithCursor(b *testing.B) {
+	pool := testdb.New(b)
+	gold := NewGoldRepository(pool)
+	ctx := context.Background()
+	at := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+	cursor := model.SyncCursor{UpdatedAt: at, ID: 1}
+
+	upserts := []struct {
+		name   string
+		upsert func(ctx context.Context, source model.NewsSource, news []*model.TranslatedNews, cursor model.SyncCursor, runCount int) (model.UpsertResult, error)
+	}{
+		{"per_row", gold.UpsertNewsWithCursor},
+		{"bulk", gold.BulkUpsertNewsWithCursor},
+	}
+
+	next := 1
+	for _, size := range []int{100, 1000, 5000} {
+		for _, u := range upserts {
+			b.Run(fmt.Sprintf("%s/%d", u.name, size), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					news := testNews(next, size, at)
+					next += size
+					b.StartTimer()
+
+					if _, err := u.upsert(ctx, model.SourceJPMinkabu, news, cursor, 0); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestUpsertRefreshesSourceUpdatedAt(t *testing.T) {
+	pool := testdb.New(t)
+	gold := NewGoldRepository(pool)
+	ctx := context.Background()
+	at := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+	cursor := model.SyncCursor{UpdatedAt: at, ID: 1}
+
+	upserts := []struct {
+		name   string
+		first  int
+		upsert func(ctx context.Context, source model.NewsSource, news []*model.TranslatedNews, cursor model.SyncCursor, runCount int) (model.UpsertResult, error)
+	}{
+		{"per_row", 1, gold.UpsertNewsWithCursor},
+		{"bulk", 100, gold.BulkUpsertNewsWithCursor},
+	}
+	for _, u := range upserts {
+		t.Run(u.name, func(t *testing.T) {
+			if _, err := u.upsert(ctx, model.SourceJPMinkabu, testNews(u.first, 1, at), cursor, 0); err != nil {
+				t.Fatal(err)
+			}
+			id := fmt.Sprintf("jp-%d", u.first)
+			var seqBefore int64
+			err := pool.QueryRow(ctx, `SELECT sync_seq FROM gold.translated_news WHERE source_news_id = $1`, id).Scan(&seqBefore)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Silver bumped updated_at without changing the content
+			later := at.Add(time.Hour)
+			bumped := testNews(u.first, 1, later)
+			bumped[0].PublishedAt = at
+			result, err := u.upsert(ctx, model.SourceJPMinkabu, bumped, cursor, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := (model.UpsertResult{Unchanged: 1}); result != want {
+				t.Errorf("result = %+v, want %+v", result, want)
+			}
+
+			var seq int64
+			var updatedAt time.Time
+			err = pool.QueryRow(ctx, `
+				SELECT sync_seq, source_updated_at FROM gold.translated_news WHERE source_news_id = $1
+			`, id).Scan(&seq, &updatedAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !updatedAt.Equal(later) {
+				t.Errorf("source_updated_at = %v, want %v", updatedAt, later)
+			}
+			if seq != seqBefore {
+				t.Errorf("sync_seq moved from %d to %d on a timestamp-only change", seqBefore, seq)
+			}
+
+			// Re-reading an older copy does not move it back
+			if _, err := u.upsert(ctx, model.SourceJPMinkabu, testNews(u.first, 1, at), cursor, 0); err != nil {
+				t.Fatal(err)
+			}
+			err = pool.QueryRow(ctx, `SELECT source_updated_at FROM gold.translated_news WHERE source_news_id = $1`, id).Scan(&updatedAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !updatedAt.Equal(later) {
+				t.Errorf("source_updated_at after an older copy = %v, want %v", updatedAt, later)
+			}
+		})
+	}
+}
+
+func TestUpsertKeepsNewerContent(t *testing.T) {
+	pool := testdb.New(t)
+	gold := NewGoldRepository(pool)
+	ctx := context.Background()
+	at := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+	cursor := model.SyncCursor{UpdatedAt: at, ID: 1}
+
+	upserts := []struct {
+		name   string
+		first  int
+		upsert func(ctx context.Context, source model.NewsSource, news []*model.TranslatedNews, cursor model.SyncCursor, runCount int) (model.UpsertResult, error)
+	}{
+		{"per_row", 1, gold.UpsertNewsWithCursor},
+		{"bulk", 100, gold.BulkUpsertNewsWithCursor},
+	}
+	for _, u := range upserts {
+		t.Run(u.name, func(t *testing.T) {
+			newer := testNews(u.first, 1, at.Add(time.Hour))
+			newer[0].TranslatedHeadline = "새 헤드라인"
+			if _, err := u.upsert(ctx, model.SourceJPMinkabu, newer, cursor, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			// An older silver copy, e.g. read by a repair before the sync above
+			result, err := u.upsert(ctx, model.SourceJPMinkabu, testNews(u.first, 1, at), cursor, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := (model.UpsertResult{Unchanged: 1}); result != want {
+				t.Errorf("result = %+v, want %+v", result, want)
+			}
+
+			var headline string
+			err = pool.QueryRow(ctx, `
+				SELECT translated_headline FROM gold.translated_news WHERE source_news_id = $1
+			`, fmt.Sprintf("jp-%d", u.first)).Scan(&headline)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if headline != "새 헤드라인" {
+				t.Errorf("headline = %q, want the newer copy", headline)
+			}
+		})
+	}
+}
@@ -2,12 +2,16 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/onelineai/hana-news-api/internal/auth"
 	"github.com/onelineai/hana-news-api/internal/model"
 )
 
@@ -20,79 +24,267 @@ func NewGoldRepository(pool *pgxpool.Pool) *GoldRepository {
 	return &GoldRepository{pool: pool}
 }
 
-// GetLastSyncTime returns the last sync time for a source
-func (r *GoldRepository) GetLastSyncTime(ctx context.Context, source model.NewsSource) (*time.Time, error) {
+// GetSyncCursor returns the last synced (updated_at, id) position for a source
+func (r *GoldRepository) GetSyncCursor(ctx context.Context, source model.NewsSource) (*model.SyncCursor, error) {
 	var lastSyncedAt *time.Time
+	var lastSyncedID *int64
 	err := r.pool.QueryRow(ctx,
-		`SELECT last_synced_at FROM gold.sync_metadata WHERE source = $1`,
+		`SELECT last_synced_at, last_synced_id FROM gold.sync_metadata WHERE source = $1`,
 		string(source),
-	).Scan(&lastSyncedAt)
+	).Scan(&lastSyncedAt, &lastSyncedID)
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
-	return lastSyncedAt, err
+	if err != nil {
+		return nil, err
+	}
+	return toSyncCursor(lastSyncedAt, lastSyncedID), nil
 }
 
-// UpdateSyncMetadata updates the sync metadata for a source
-func (r *GoldRepository) UpdateSyncMetadata(ctx context.Context, source model.NewsSource, syncedAt time.Time, count int) error {
+// ResetSyncCursor moves a source's sync cursor, e.g. back in time for a backfill
+func (r *GoldRepository) ResetSyncCursor(ctx context.Context, source model.NewsSource, cursor model.SyncCursor) error {
 	_, err := r.pool.Exec(ctx, `
-		UPDATE gold.sync_metadata 
-		SET last_synced_at = $1, last_sync_count = $2, updated_at = NOW()
-		WHERE source = $3
-	`, syncedAt, count, string(source))
+		INSERT INTO gold.sync_metadata (source, last_synced_at, last_synced_id, last_sync_count, updated_at)
+		VALUES ($1, $2, $3, 0, NOW())
+		ON CONFLICT (source) DO UPDATE SET
+			last_synced_at = EXCLUDED.last_synced_at,
+			last_synced_id = EXCLUDED.last_synced_id,
+			updated_at = NOW()
+	`, string(source), cursor.UpdatedAt, cursor.ID)
 	return err
 }
 
-// UpsertNews upserts translated news records into the unified table
-func (r *GoldRepository) UpsertNews(ctx context.Context, news []*model.TranslatedNews) (int, error) {
+// UpsertNews upserts translated news records into the unified table.
+// Rows whose content hash is unchanged only have source_updated_at moved forward.
+func (r *GoldRepository) UpsertNews(ctx context.Context, news []*model.TranslatedNews) (model.UpsertResult, error) {
+	result, err := upsertNews(ctx, r.pool, news)
+	if err != nil {
+		return result, err
+	}
+	return result, r.AssignNewsSeq(ctx)
+}
+
+// UpsertNewsWithCursor upserts a batch and advances the source's sync cursor in one transaction,
+// so a sync interrupted between batches resumes right after the last committed batch.
+// runCount is the number of rows already written by the current run.
+func (r *GoldRepository) UpsertNewsWithCursor(ctx context.Context, source model.NewsSource, news []*model.TranslatedNews, cursor model.SyncCursor, runCount int) (model.UpsertResult, error) {
+	return r.withSyncCursor(ctx, source, cursor, runCount, func(tx pgx.Tx) (model.UpsertResult, error) {
+		return upsertNews(ctx, tx, news)
+	})
+}
+
+// withSyncCursor runs upsert and then advances the source's sync cursor in the same transaction
+func (r *GoldRepository) withSyncCursor(ctx context.Context, source model.NewsSource, cursor model.SyncCursor, runCount int, upsert func(tx pgx.Tx) (model.UpsertResult, error)) (model.UpsertResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.UpsertResult{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := upsert(tx)
+	if err != nil {
+		return model.UpsertResult{}, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO gold.sync_metadata (source, last_synced_at, last_synced_id, last_sync_count, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (source) DO UPDATE SET
+			last_synced_at = EXCLUDED.last_synced_at,
+			last_synced_id = EXCLUDED.last_synced_id,
+			last_sync_count = EXCLUDED.last_sync_count,
+			updated_at = NOW()
+	`, string(source), cursor.UpdatedAt, cursor.ID, runCount+result.Written())
+	if err != nil {
+		return model.UpsertResult{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.UpsertResult{}, err
+	}
+	return result, r.AssignNewsSeq(ctx)
+}
+
+// newsUpsertConflict is shared by the per-row and bulk upserts. The WHERE on DO UPDATE
+// skips rows whose content is unchanged (no row is returned), unless the row was
+// soft-deleted and needs restoring, and never lets an older silver copy overwrite a
+// newer one (e.g. a reconcile repair racing a sync). Written rows get their sync_seq
+// from AssignNewsSeq after commit. xmax = 0 only for fresh inserts.
+const newsUpsertConflict = `
+	ON CONFLICT (source, source_news_id) DO UPDATE SET
+		original_headline = EXCLUDED.original_headline,
+		original_content = EXCLUDED.original_content,
+		translated_headline = EXCLUDED.translated_headline,
+		translated_content = EXCLUDED.translated_content,
+		tickers = EXCLUDED.tickers,
+		topics = EXCLUDED.topics,
+		keywords = EXCLUDED.keywords,
+		provider = EXCLUDED.provider,
+		published_at = EXCLUDED.published_at,
+		model_name = EXCLUDED.model_name,
+		source_updated_at = EXCLUDED.source_updated_at,
+		content_hash = EXCLUDED.content_hash,
+		synced_at = NOW(),
+		sync_seq = NULL,
+		deleted_at = NULL
+	WHERE (gold.translated_news.content_hash IS DISTINCT FROM EXCLUDED.content_hash
+	       OR gold.translated_news.deleted_at IS NOT NULL)
+	  AND (gold.translated_news.source_updated_at IS NULL
+	       OR EXCLUDED.source_updated_at IS NULL
+	       OR EXCLUDED.source_updated_at >= gold.translated_news.source_updated_at)
+	RETURNING (xmax = 0) AS inserted
+`
+
+// newsKey identifies a gold row by its source row
+type newsKey struct {
+	source model.NewsSource
+	id     string
+}
+
+// queueRefreshSourceUpdatedAt queues an update moving source_updated_at forward on
+// rows whose content is unchanged, which the upsert leaves untouched. It runs after
+// the upsert and does not bump sync_seq, so subscribers are not sent the same
+// content again. Reports whether anything was queued.
+func queueRefreshSourceUpdatedAt(batch *pgx.Batch, news []*model.TranslatedNews) bool {
+	latest := make(map[newsKey]*model.TranslatedNews, len(news))
+	for _, n := range news {
+		if n.SourceUpdatedAt == nil {
+			continue
+		}
+		key := newsKey{n.Source, n.SourceNewsID}
+		if l, ok := latest[key]; !ok || n.SourceUpdatedAt.After(*l.SourceUpdatedAt) {
+			latest[key] = n
+		}
+	}
+	if len(latest) == 0 {
+		return false
+	}
+
+	sources := make([]string, 0, len(latest))
+	ids := make([]string, 0, len(latest))
+	updatedAt := make([]time.Time, 0, len(latest))
+	hashes := make([]string, 0, len(latest))
+	for _, n := range latest {
+		sources = append(sources, string(n.Source))
+		ids = append(ids, n.SourceNewsID)
+		updatedAt = append(updatedAt, *n.SourceUpdatedAt)
+		hashes = append(hashes, n.ContentHash())
+	}
+
+	batch.Queue(`
+		UPDATE gold.translated_news n
+		SET source_updated_at = u.updated_at
+		FROM unnest($1::text[], $2::text[], $3::timestamptz[], $4::text[])
+			AS u(source, source_news_id, updated_at, content_hash)
+		WHERE n.source = u.source AND n.source_news_id = u.source_news_id
+		  AND n.content_hash = u.content_hash
+		  AND (n.source_updated_at IS NULL OR n.source_updated_at < u.updated_at)
+	`, sources, ids, updatedAt, hashes)
+	return true
+}
+
+// batchSender is satisfied by both *pgxpool.Pool and pgx.Tx
+type batchSender interface {
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+func upsertNews(ctx context.Context, db batchSender, news []*model.TranslatedNews) (model.UpsertResult, error) {
+	var result model.UpsertResult
 	if len(news) == 0 {
-		return 0, nil
+		return result, nil
 	}
 
+	// The batch runs in one (implicit) transaction; written rows wait for a sync
+	// sequence until it commits
 	batch := &pgx.Batch{}
 	for _, n := range news {
 		batch.Queue(`
 			INSERT INTO gold.translated_news 
 				(id, source, source_news_id, original_headline, original_content,
 				 translated_headline, translated_content, tickers, topics, keywords,
-				 provider, published_at, model_name, source_created_at, source_updated_at, synced_at)
-			VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW())
-			ON CONFLICT (source, source_news_id) DO UPDATE SET
-				original_headline = EXCLUDED.original_headline,
-				original_content = EXCLUDED.original_content,
-				translated_headline = EXCLUDED.translated_headline,
-				translated_content = EXCLUDED.translated_content,
-				tickers = EXCLUDED.tickers,
-				topics = EXCLUDED.topics,
-				keywords = EXCLUDED.keywords,
-				provider = EXCLUDED.provider,
-				published_at = EXCLUDED.published_at,
-				model_name = EXCLUDED.model_name,
-				source_updated_at = EXCLUDED.source_updated_at,
-				synced_at = NOW()
-		`, n.Source, n.SourceNewsID, n.OriginalHeadline, n.OriginalContent,
+				 provider, published_at, model_name, source_created_at, source_updated_at,
+				 content_hash, synced_at)
+			VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NOW())
+		`+newsUpsertConflict,
+			n.Source, n.SourceNewsID, n.OriginalHeadline, n.OriginalContent,
 			n.TranslatedHeadline, n.TranslatedContent, n.Tickers, n.Topics, n.Keywords,
-			n.Provider, n.PublishedAt, n.ModelName, n.SourceCreatedAt, n.SourceUpdatedAt)
+			n.Provider, n.PublishedAt, n.ModelName, n.SourceCreatedAt, n.SourceUpdatedAt,
+			n.ContentHash())
 	}
+	refresh := queueRefreshSourceUpdatedAt(batch, news)
+	touch := queueTouchInstruments(batch, news)
 
-	results := r.pool.SendBatch(ctx, batch)
+	results := db.SendBatch(ctx, batch)
 	defer results.Close()
 
-	affected := 0
 	for range news {
-		ct, err := results.Exec()
-		if err != nil {
-			return affected, err
+		var inserted bool
+		err := results.QueryRow().Scan(&inserted)
+		switch {
+		case err == pgx.ErrNoRows:
+			result.Unchanged++
+		case err != nil:
+			return result, err
+		case inserted:
+			result.Inserted++
+		default:
+			result.Updated++
+		}
+	}
+	for _, queued := range []bool{refresh, touch} {
+		if !queued {
+			continue
+		}
+		if _, err := results.Exec(); err != nil {
+			return result, err
 		}
-		affected += int(ct.RowsAffected())
 	}
-	return affected, nil
+	return result, nil
+}
+
+// ListLiveSourceNewsIDs returns source news IDs of live (not deleted) rows for a source,
+// ordered by source_news_id and starting after the given ID
+func (r *GoldRepository) ListLiveSourceNewsIDs(ctx context.Context, source model.NewsSource, after string, limit int) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT source_news_id
+		FROM gold.translated_news
+		WHERE source = $1 AND deleted_at IS NULL AND source_news_id > $2
+		ORDER BY source_news_id
+		LIMIT $3
+	`, string(source), after, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// MarkNewsDeleted soft-deletes the given rows of a source and returns how many were marked
+func (r *GoldRepository) MarkNewsDeleted(ctx context.Context, source model.NewsSource, sourceNewsIDs []string) (int, error) {
+	ct, err := r.pool.Exec(ctx, `
+		UPDATE gold.translated_news
+		SET deleted_at = NOW()
+		WHERE source = $1 AND source_news_id = ANY($2) AND deleted_at IS NULL
+	`, string(source), sourceNewsIDs)
+	if err != nil {
+		return 0, err
+	}
+	return int(ct.RowsAffected()), nil
 }
 
-// ListNews returns paginated news list with optional filtering
-func (r *GoldRepository) ListNews(ctx context.Context, filter model.NewsFilter) ([]model.NewsListItem, int, error) {
+// newsQuery is the WHERE clause shared by news listing and counting
+type newsQuery struct {
+	where  string
+	args   []interface{}
+	argIdx int
+	scored *textSearch // search driving the relevance score, nil without keywords
+	// tickerArg is the placeholder of the requested tickers, 0 without a ticker filter
+	tickerArg int
+}
+
+// buildNewsQuery translates a news filter into SQL conditions
+func buildNewsQuery(filter model.NewsFilter) newsQuery {
 	// Build WHERE clause
-	var conditions []string
+	conditions := []string{"deleted_at IS NULL"}
 	var args []interface{}
 	argIdx := 1
 
@@ -102,12 +294,96 @@ func (r *GoldRepository) ListNews(ctx context.Context, filter model.NewsFilter)
 		argIdx++
 	}
 
-	if filter.Ticker != nil {
-		conditions = append(conditions, fmt.Sprintf("$%d = ANY(tickers)", argIdx))
-		args = append(args, *filter.Ticker)
+	if filter.Sources != nil {
+		conditions = append(conditions, fmt.Sprintf("source = ANY($%d::text[])", argIdx))
+		args = append(args, sourceStrings(filter.Sources))
+		argIdx++
+	}
+
+	// Array overlap uses the tickers GIN index
+	tickerArg := 0
+	if len(filter.Tickers) > 0 {
+		conditions = append(conditions, fmt.Sprintf("tickers && $%d::text[]", argIdx))
+		args = append(args, filter.Tickers)
+		tickerArg = argIdx
+		argIdx++
+	}
+
+	if filter.SourceNewsID != nil {
+		conditions = append(conditions, fmt.Sprintf("source_news_id = $%d", argIdx))
+		args = append(args, *filter.SourceNewsID)
 		argIdx++
 	}
 
+	// Array filters use the GIN-indexable && (any) and @> (all) operators
+	arrayFilters := []struct {
+		column string
+		filter model.ArrayFilter
+	}{
+		{"topics", filter.Topics},
+		{"keywords", filter.Keywords},
+	}
+	for _, af := range arrayFilters {
+		if len(af.filter.Values) > 0 {
+			op := "&&"
+			if af.filter.Match == model.MatchAll {
+				op = "@>"
+			}
+			conditions = append(conditions, fmt.Sprintf("%s %s $%d::text[]", af.column, op, argIdx))
+			args = append(args, af.filter.Values)
+			argIdx++
+		}
+		if len(af.filter.Exclude) > 0 {
+			conditions = append(conditions, fmt.Sprintf("NOT (%s && $%d::text[])", af.column, argIdx))
+			args = append(args, af.filter.Exclude)
+			argIdx++
+		}
+	}
+
+	if len(filter.Providers) > 0 {
+		conditions = append(conditions, fmt.Sprintf("provider = ANY($%d::text[])", argIdx))
+		args = append(args, filter.Providers)
+		argIdx++
+	}
+
+	if len(filter.ExcludeProviders) > 0 {
+		// News without a provider are kept
+		conditions = append(conditions, fmt.Sprintf("(provider IS NULL OR provider <> ALL($%d::text[]))", argIdx))
+		args = append(args, filter.ExcludeProviders)
+		argIdx++
+	}
+
+	// Every search term must appear in the headline or content. ILIKE uses the trigram
+	// indexes; two-character terms, common in Korean, use the bigram indexes instead.
+	// The first search with terms drives the relevance score.
+	searches := []textSearch{
+		{headline: "translated_headline", content: "translated_content", query: filter.Query},
+		{headline: "original_headline", content: "original_content", query: filter.OriginalQuery},
+	}
+	var scored *textSearch
+	for i := range searches {
+		search := &searches[i]
+		if search.query != nil {
+			search.terms = model.SearchTerms(*search.query)
+		}
+		for _, term := range search.terms {
+			if utf8.RuneCountInString(term) <= 2 {
+				conditions = append(conditions, fmt.Sprintf(
+					"(gold.text_bigrams(%s) @> ARRAY[lower($%d)] OR gold.text_bigrams(%s) @> ARRAY[lower($%d)])",
+					search.headline, argIdx, search.content, argIdx))
+				args = append(args, term)
+			} else {
+				conditions = append(conditions, fmt.Sprintf(
+					"(%s ILIKE $%d OR %s ILIKE $%d)", search.headline, argIdx, search.content, argIdx))
+				args = append(args, "%"+escapeLike(term)+"%")
+			}
+			argIdx++
+		}
+		if scored == nil && len(search.terms) > 0 {
+			scored = search
+		}
+	}
+
 	if filter.From != nil {
 		conditions = append(conditions, fmt.Sprintf("published_at >= $%d", argIdx))
 		args = append(args, *filter.From)
@@ -120,32 +396,87 @@ func (r *GoldRepository) ListNews(ctx context.Context, filter model.NewsFilter)
 		argIdx++
 	}
 
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	return newsQuery{
+		where:     "WHERE " + strings.Join(conditions, " AND "),
+		args:      args,
+		argIdx:    argIdx,
+		scored:    scored,
+		tickerArg: tickerArg,
+	}
+}
+
+// scopeNewsFilter restricts a filter to the sources the caller is licensed for
+func scopeNewsFilter(ctx context.Context, filter model.NewsFilter) model.NewsFilter {
+	if allowed := auth.AllowedSources(ctx); allowed != nil {
+		filter.Sources = allowed
+	}
+	return filter
+}
+
+func sourceStrings(sources []model.NewsSource) []string {
+	out := make([]string, len(sources))
+	for i, s := range sources {
+		out[i] = string(s)
 	}
+	return out
+}
+
+// ListNews returns one page of news and whether more rows follow in the paging direction.
+// Keyset cursors page on (published_at, id); otherwise LIMIT/OFFSET is used.
+func (r *GoldRepository) ListNews(ctx context.Context, filter model.NewsFilter) ([]model.NewsListItem, bool, error) {
+	q := buildNewsQuery(scopeNewsFilter(ctx, filter))
+	whereClause, args, argIdx := q.where, q.args, q.argIdx
 
-	// Count query
-	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM gold.translated_news %s`, whereClause)
-	var total int
-	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
-		return nil, 0, err
+	orderBy := "published_at DESC, id DESC"
+	backward := filter.Cursor != nil && filter.Cursor.Backward
+	if filter.Cursor != nil {
+		op := "<"
+		if backward {
+			// Walk towards newer rows, reversed again after scanning
+			op = ">"
+			orderBy = "published_at ASC, id ASC"
+		}
+		whereClause += fmt.Sprintf(" AND (published_at, id) %s ($%d, $%d::uuid)", op, argIdx, argIdx+1)
+		args = append(args, filter.Cursor.PublishedAt, filter.Cursor.ID)
+		argIdx += 2
 	}
 
-	// Data query with pagination
-	offset := (filter.Page - 1) * filter.Limit
-	dataArgs := append(args, filter.Limit, offset)
+	// Relevance score, headline matches weigh double
+	scoreExpr := "0::float8"
+	if q.scored != nil {
+		scoreExpr = fmt.Sprintf(
+			"(2 * word_similarity($%d, %s) + word_similarity($%d, COALESCE(%s, ''))) / 3",
+			argIdx, q.scored.headline, argIdx, q.scored.content)
+		args = append(args, strings.Join(q.scored.terms, " "))
+		argIdx++
+		if filter.Sort == model.SortRelevance {
+			orderBy = "score DESC, published_at DESC, id DESC"
+		}
+	}
+
+	matchedExpr := "NULL::text[]"
+	if q.tickerArg > 0 {
+		matchedExpr = fmt.Sprintf("ARRAY(SELECT t FROM unnest(tickers) AS t WHERE t = ANY($%d::text[]))", q.tickerArg)
+	}
+
+	// Data query with pagination; one extra row tells whether another page exists
+	offset := 0
+	if filter.Cursor == nil {
+		offset = (filter.Page - 1) * filter.Limit
+	}
+	args = append(args, filter.Limit+1, offset)
 	dataQuery := fmt.Sprintf(`
-		SELECT id, translated_headline, translated_content, published_at, provider
+		SELECT id, translated_headline, translated_content, published_at, provider,
+			%s AS score, %s AS matched_tickers
 		FROM gold.translated_news
 		%s
-		ORDER BY published_at DESC
+		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, whereClause, argIdx, argIdx+1)
+	`, scoreExpr, matchedExpr, whereClause, orderBy, argIdx, argIdx+1)
 
-	rows, err := r.pool.Query(ctx, dataQuery, dataArgs...)
+	rows, err := r.pool.Query(ctx, dataQuery, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, false, err
 	}
 	defer rows.Close()
 
@@ -156,23 +487,87 @@ func (r *GoldRepository) ListNews(ctx context.Context, filter model.NewsFilter)
 		var content *string
 		var publishedAt time.Time
 		var provider *string
-		if err := rows.Scan(&id, &headline, &content, &publishedAt, &provider); err != nil {
-			return nil, 0, err
+		var score float64
+		var matched []string
+		if err := rows.Scan(&id, &headline, &content, &publishedAt, &provider, &score, &matched); err != nil {
+			return nil, false, err
+		}
+		item := model.NewsListItem{
+			ID:             id,
+			Date:           publishedAt.Format("2006.01.02"),
+			Time:           publishedAt.Format("15:04"),
+			Publisher:      provider,
+			Headline:       headline,
+			Content:        content,
+			MatchedTickers: matched,
+			PublishedAt:    publishedAt,
+		}
+		if q.scored != nil {
+			item.Score = &score
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(items) > filter.Limit
+	if hasMore {
+		items = items[:filter.Limit]
+	}
+	if backward {
+		slices.Reverse(items)
+	}
+	return items, hasMore, nil
+}
+
+// CountNews returns the number of news matching the filter. With estimate set,
+// the planner's row estimate is returned instead of running COUNT(*).
+func (r *GoldRepository) CountNews(ctx context.Context, filter model.NewsFilter, estimate bool) (int, error) {
+	q := buildNewsQuery(scopeNewsFilter(ctx, filter))
+
+	if !estimate {
+		countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM gold.translated_news %s`, q.where)
+		var total int
+		if err := r.pool.QueryRow(ctx, countQuery, q.args...).Scan(&total); err != nil {
+			return 0, err
 		}
-		items = append(items, model.NewsListItem{
-			ID:        id,
-			Date:      publishedAt.Format("2006.01.02"),
-			Time:      publishedAt.Format("15:04"),
-			Publisher: provider,
-			Headline:  headline,
-			Content:   content,
-		})
+		return total, nil
+	}
+
+	explainQuery := fmt.Sprintf(`EXPLAIN (FORMAT JSON) SELECT 1 FROM gold.translated_news %s`, q.where)
+	var raw string
+	if err := r.pool.QueryRow(ctx, explainQuery, q.args...).Scan(&raw); err != nil {
+		return 0, err
+	}
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+		return 0, fmt.Errorf("parse query plan: %w", err)
+	}
+	if len(plans) == 0 {
+		return 0, nil
 	}
+	return int(plans[0].Plan.Rows), nil
+}
+
+// textSearch describes keyword matching over a headline/content column pair
+type textSearch struct {
+	headline string
+	content  string
+	query    *string
+	terms    []string
+}
 
-	return items, total, rows.Err()
+// escapeLike escapes LIKE wildcards so user input is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
 }
 
-// GetNewsDetail returns detailed news by UUID
+// GetNewsDetail returns detailed news by UUID, including soft-deleted rows
 func (r *GoldRepository) GetNewsDetail(ctx context.Context, id string) (*model.NewsDetail, error) {
 	var detail model.NewsDetail
 	var sourceStr string
@@ -180,13 +575,13 @@ func (r *GoldRepository) GetNewsDetail(ctx context.Context, id string) (*model.N
 	err := r.pool.QueryRow(ctx, `
 		SELECT id, source, original_headline, original_content,
 		       translated_headline, translated_content, tickers, topics, keywords,
-		       published_at, provider, model_name
+		       published_at, provider, model_name, deleted_at
 		FROM gold.translated_news
 		WHERE id = $1
 	`, id).Scan(
 		&detail.ID, &sourceStr, &detail.OriginalHeadline, &detail.OriginalContent,
-		&detail.TranslatedHeadline, &detail.TranslatedContent, &detail.Tickers, &detail.Topics, &detail.Keywords,
-		&detail.PublishedAt, &detail.Provider, &detail.ModelName,
+		&detail.TranslatedHeadline, &detail.TranslatedContent, &detail.TickerCodes, &detail.Topics, &detail.Keywords,
+		&detail.PublishedAt, &detail.Provider, &detail.ModelName, &detail.DeletedAt,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -198,5 +593,10 @@ func (r *GoldRepository) GetNewsDetail(ctx context.Context, id string) (*model.N
 
 	detail.Source = model.NewsSource(sourceStr)
 
+	// News outside the caller's license do not exist for it
+	if allowed := auth.AllowedSources(ctx); allowed != nil && !slices.Contains(allowed, detail.Source) {
+		return nil, nil
+	}
+
 	return &detail, nil
 }
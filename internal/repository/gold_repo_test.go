@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/testdb"
+)
+
+func TestListNewsShortSearchTerms(t *testing.T) {
+	pool := testdb.New(t)
+	gold := NewGoldRepository(pool)
+	ctx := context.Background()
+	at := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+
+	news := testNews(1, 3, at)
+	news[0].TranslatedHeadline, news[0].OriginalHeadline = "한은 기준금리 동결", "日銀が金利据え置き"
+	news[1].TranslatedHeadline, news[1].OriginalHeadline = "원달러 환율 급등", "円相場が急落"
+	news[2].TranslatedHeadline, news[2].OriginalHeadline = "Toyota 株価 상승", "トヨタ株価上昇"
+	if _, err := gold.UpsertNews(ctx, news); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		q, originalQ string
+		want         []string
+	}{
+		{q: "금리", want: []string{news[0].TranslatedHeadline}},
+		{q: "기준금리", want: []string{news[0].TranslatedHeadline}},
+		{q: "환율 급등", want: []string{news[1].TranslatedHeadline}},
+		{q: "toyota 株価", want: []string{news[2].TranslatedHeadline}},
+		// Pairs are not formed across spaces, as with ILIKE
+		{q: "리동", want: nil},
+		{originalQ: "株価", want: []string{news[2].TranslatedHeadline}},
+		{originalQ: "相場", want: []string{news[1].TranslatedHeadline}},
+	}
+	for _, tt := range tests {
+		filter := model.NewsFilter{Page: 1, Limit: 10, Sort: model.SortLatest}
+		if tt.q != "" {
+			filter.Query = &tt.q
+		}
+		if tt.originalQ != "" {
+			filter.OriginalQuery = &tt.originalQ
+		}
+		items, _, err := gold.ListNews(ctx, filter)
+		if err != nil {
+			t.Fatalf("q=%q original_q=%q: %v", tt.q, tt.originalQ, err)
+		}
+		var got []string
+		for _, item := range items {
+			got = append(got, item.Headline)
+		}
+		if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+			t.Errorf("q=%q original_q=%q: got %v, want %v", tt.q, tt.originalQ, got, tt.want)
+		}
+	}
+}
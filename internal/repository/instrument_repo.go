@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// queueTouchInstruments queues an upsert registering the tickers of news in
+// gold.instruments, and reports whether anything was queued. Tickers are sorted
+// so concurrent syncs lock instrument rows in the same order.
+func queueTouchInstruments(batch *pgx.Batch, news []*model.TranslatedNews) bool {
+	type seenSpan struct{ first, last time.Time }
+	spans := make(map[string]seenSpan)
+	for _, n := range news {
+		for _, t := range n.Tickers {
+			span, ok := spans[t]
+			if !ok || n.PublishedAt.Before(span.first) {
+				span.first = n.PublishedAt
+			}
+			if !ok || n.PublishedAt.After(span.last) {
+				span.last = n.PublishedAt
+			}
+			spans[t] = span
+		}
+	}
+	if len(spans) == 0 {
+		return false
+	}
+
+	tickers := make([]string, 0, len(spans))
+	for t := range spans {
+		tickers = append(tickers, t)
+	}
+	slices.Sort(tickers)
+
+	exchanges := make([]string, len(tickers))
+	firstSeen := make([]time.Time, len(tickers))
+	lastSeen := make([]time.Time, len(tickers))
+	for i, t := range tickers {
+		exchanges[i] = model.TickerExchange(t)
+		firstSeen[i] = spans[t].first
+		lastSeen[i] = spans[t].last
+	}
+
+	batch.Queue(`
+		INSERT INTO gold.instruments (ticker, exchange, first_seen_at, last_seen_at)
+		SELECT t, e, f, l FROM unnest($1::text[], $2::text[], $3::timestamptz[], $4::timestamptz[]) AS u(t, e, f, l)
+		ON CONFLICT (ticker) DO UPDATE SET
+			first_seen_at = LEAST(gold.instruments.first_seen_at, EXCLUDED.first_seen_at),
+			last_seen_at = GREATEST(gold.instruments.last_seen_at, EXCLUDED.last_seen_at)
+		WHERE gold.instruments.first_seen_at IS NULL
+		   OR gold.instruments.last_seen_at IS NULL
+		   OR EXCLUDED.last_seen_at > gold.instruments.last_seen_at
+		   OR EXCLUDED.first_seen_at < gold.instruments.first_seen_at
+	`, tickers, exchanges, firstSeen, lastSeen)
+	return true
+}
+
+// ListInstrumentAliases returns upper-cased ISINs and aliases keyed to their canonical
+// ticker. Only master rows carry them; tickers registered by sync are skipped.
+func (r *GoldRepository) ListInstrumentAliases(ctx context.Context) (map[string]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT ticker, isin, aliases
+		FROM gold.instruments
+		WHERE isin IS NOT NULL OR aliases <> '{}'
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aliases := make(map[string]string)
+	for rows.Next() {
+		var (
+			ticker string
+			isin   *string
+			others []string
+		)
+		if err := rows.Scan(&ticker, &isin, &others); err != nil {
+			return nil, err
+		}
+		if isin != nil {
+			aliases[strings.ToUpper(*isin)] = ticker
+		}
+		for _, a := range others {
+			aliases[strings.ToUpper(a)] = ticker
+		}
+	}
+	return aliases, rows.Err()
+}
+
+// GetInstruments returns the instruments of canonical tickers. Unknown tickers are absent.
+func (r *GoldRepository) GetInstruments(ctx context.Context, tickers []string) ([]model.Instrument, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+instrumentColumns+`
+		FROM gold.instruments
+		WHERE ticker = ANY($1)
+	`, tickers)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.Instrument, error) {
+		return scanInstrument(row)
+	})
+}
+
+const instrumentColumns = `
+	ticker, exchange, isin, name_ko, name_ja, name_zh, aliases, first_seen_at, last_seen_at, updated_at
+`
+
+func scanInstrument(row pgx.Row) (model.Instrument, error) {
+	var inst model.Instrument
+	err := row.Scan(&inst.Ticker, &inst.Exchange, &inst.ISIN, &inst.NameKO, &inst.NameJA, &inst.NameZH,
+		&inst.Aliases, &inst.FirstSeenAt, &inst.LastSeenAt, &inst.UpdatedAt)
+	return inst, err
+}
+
+// SearchInstruments returns instruments matching a ticker prefix, ISIN, alias or
+// company name. Ticker prefix matches rank first, then name similarity.
+func (r *GoldRepository) SearchInstruments(ctx context.Context, filter model.InstrumentFilter) ([]model.Instrument, int, error) {
+	var conditions []string
+	var args []interface{}
+	argIdx := 1
+
+	var q string
+	if filter.Query != nil {
+		q = strings.TrimSpace(*filter.Query)
+		code := strings.ToUpper(q)
+		conditions = append(conditions, fmt.Sprintf(`(
+			ticker LIKE $%d OR isin = $%d OR $%d = ANY(aliases)
+			OR name_ko ILIKE $%d OR name_ja ILIKE $%d OR name_zh ILIKE $%d
+		)`, argIdx, argIdx+1, argIdx+1, argIdx+2, argIdx+2, argIdx+2))
+		args = append(args, escapeLike(code)+"%", code, "%"+escapeLike(q)+"%")
+		argIdx += 3
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Count query
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM gold.instruments %s`, whereClause)
+	var total int
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// Ticker prefix matches first, then the closest company name
+	dataArgs := args
+	orderBy := "last_seen_at DESC NULLS LAST, ticker"
+	if filter.Query != nil {
+		orderBy = fmt.Sprintf(`(ticker LIKE $1) DESC,
+			GREATEST(similarity(COALESCE(name_ko, ''), $%d), similarity(COALESCE(name_ja, ''), $%d),
+			         similarity(COALESCE(name_zh, ''), $%d)) DESC,
+			%s`, argIdx, argIdx, argIdx, orderBy)
+		dataArgs = append(dataArgs, q)
+		argIdx++
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	dataArgs = append(dataArgs, filter.Limit, offset)
+	dataQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM gold.instruments
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, instrumentColumns, whereClause, orderBy, argIdx, argIdx+1)
+
+	rows, err := r.pool.Query(ctx, dataQuery, dataArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.Instrument, error) {
+		return scanInstrument(row)
+	})
+	return items, total, err
+}
+
+// GetInstrument returns an instrument by canonical ticker, or nil if unknown
+func (r *GoldRepository) GetInstrument(ctx context.Context, ticker string) (*model.Instrument, error) {
+	inst, err := scanInstrument(r.pool.QueryRow(ctx, `
+		SELECT `+instrumentColumns+`
+		FROM gold.instruments
+		WHERE ticker = $1
+	`, ticker))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &inst, nil
+}
+
+// UpsertInstruments writes master data rows. Blank exchanges, names, ISINs and nil
+// aliases keep the stored values, so a partial master does not erase data maintained
+// elsewhere; new rows without an exchange get the one inferred from the ticker.
+func (r *GoldRepository) UpsertInstruments(ctx context.Context, instruments []model.Instrument) error {
+	batch := &pgx.Batch{}
+	for _, inst := range instruments {
+		var exchange *string
+		if inst.Exchange != "" {
+			exchange = &inst.Exchange
+		}
+		batch.Queue(`
+			INSERT INTO gold.instruments (ticker, exchange, isin, name_ko, name_ja, name_zh, aliases)
+			VALUES ($1, COALESCE($2, $8), $3, $4, $5, $6, COALESCE($7::text[], '{}'))
+			ON CONFLICT (ticker) DO UPDATE SET
+				exchange = COALESCE($2, gold.instruments.exchange),
+				isin = COALESCE(EXCLUDED.isin, gold.instruments.isin),
+				name_ko = COALESCE(EXCLUDED.name_ko, gold.instruments.name_ko),
+				name_ja = COALESCE(EXCLUDED.name_ja, gold.instruments.name_ja),
+				name_zh = COALESCE(EXCLUDED.name_zh, gold.instruments.name_zh),
+				aliases = COALESCE($7::text[], gold.instruments.aliases),
+				updated_at = NOW()
+		`, inst.Ticker, exchange, inst.ISIN, inst.NameKO, inst.NameJA, inst.NameZH, inst.Aliases,
+			model.TickerExchange(inst.Ticker))
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/testdb"
+)
+
+// TestNormalizeTickerMatchesSQL keeps model.NormalizeTicker and gold.normalize_ticker in step
+func TestNormalizeTickerMatchesSQL(t *testing.T) {
+	pool := testdb.New(t)
+	ctx := context.Background()
+
+	inputs := []string{
+		"", "  ", "7203", "7203.T", "7203.t", "7203.JP", "7203.TYO", "7203.TSE", " 72 03 ",
+		"130A", "130a.T", "600519", "600519.SS", "600519.SHG", "900901",
+		"000001", "000001.SHE", "300750.sz", "830799", "430047.BJ", "00700.HK",
+		"123456", "72030", "AAPL", "aapl.o", "BRK.B", "7203.", ".T", "1.2.SH", "7203\tT",
+	}
+	for _, in := range inputs {
+		var sql string
+		if err := pool.QueryRow(ctx, `SELECT gold.normalize_ticker($1)`, in).Scan(&sql); err != nil {
+			t.Fatal(err)
+		}
+		if got := model.NormalizeTicker(in); got != sql {
+			t.Errorf("NormalizeTicker(%q) = %q, gold.normalize_ticker = %q", in, got, sql)
+		}
+	}
+}
+
+func TestUpsertNewsTracksInstrumentSeenSpan(t *testing.T) {
+	pool := testdb.New(t)
+	gold := NewGoldRepository(pool)
+	ctx := context.Background()
+	at := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+
+	// A backfill batch spanning several days, newest first
+	news := testNews(1, 3, at)
+	for i, n := range news {
+		n.PublishedAt = at.Add(-time.Duration(i) * 24 * time.Hour)
+		n.Tickers = []string{"7203.T"}
+	}
+	if _, err := gold.UpsertNews(ctx, news); err != nil {
+		t.Fatal(err)
+	}
+
+	inst, err := gold.GetInstrument(ctx, "7203.T")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inst == nil || inst.FirstSeenAt == nil || inst.LastSeenAt == nil {
+		t.Fatalf("instrument = %+v, want first and last seen times", inst)
+	}
+	if want := at.Add(-48 * time.Hour); !inst.FirstSeenAt.Equal(want) {
+		t.Errorf("first_seen_at = %v, want %v", inst.FirstSeenAt, want)
+	}
+	if !inst.LastSeenAt.Equal(at) {
+		t.Errorf("last_seen_at = %v, want %v", inst.LastSeenAt, at)
+	}
+}
+
+func TestListInstrumentAliasesSkipsSyncedTickers(t *testing.T) {
+	pool := testdb.New(t)
+	gold := NewGoldRepository(pool)
+	ctx := context.Background()
+
+	news := testNews(1, 1, time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC))
+	news[0].Tickers = []string{"7203.T", "9984.T"}
+	if _, err := gold.UpsertNews(ctx, news); err != nil {
+		t.Fatal(err)
+	}
+	isin := "jp3633400001"
+	if err := gold.UpsertInstruments(ctx, []model.Instrument{
+		{Ticker: "7203.T", ISIN: &isin, Aliases: []string{"toyota"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	aliases, err := gold.ListInstrumentAliases(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"JP3633400001": "7203.T", "TOYOTA": "7203.T"}
+	if len(aliases) != len(want) {
+		t.Fatalf("aliases = %v, want %v", aliases, want)
+	}
+	for k, v := range want {
+		if aliases[k] != v {
+			t.Errorf("aliases[%q] = %q, want %q", k, aliases[k], v)
+		}
+	}
+
+	list, err := gold.GetInstruments(ctx, []string{"7203.T", "9984.T", "6758.T"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 {
+		t.Errorf("GetInstruments returned %d rows, want 2", len(list))
+	}
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// TakeRateLimitTokens refills the buckets for keys at rates tokens per second up to
+// bursts and takes one token from each bucket that has a whole one, all in one
+// statement. Returns the tokens left and whether one was taken, in the order of keys.
+func (r *GoldRepository) TakeRateLimitTokens(ctx context.Context, keys []string, rates []float64, bursts []int) ([]float64, []bool, error) {
+	// Existing buckets are locked in key order and read at their latest version, so
+	// concurrent requests never take the same token. New buckets start full; if another
+	// request creates the same bucket first, this request is let through unrecorded.
+	rows, err := r.pool.Query(ctx, `
+		WITH req AS (
+			SELECT key, rate, burst::float8 AS burst
+			FROM unnest($1::text[], $2::float8[], $3::int[]) AS u(key, rate, burst)
+		), refilled AS (
+			SELECT b.key, LEAST(req.burst, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * req.rate) AS level
+			FROM gold.rate_limit_buckets b
+			JOIN req ON req.key = b.key
+			ORDER BY b.key
+			FOR UPDATE OF b
+		), updated AS (
+			UPDATE gold.rate_limit_buckets b
+			SET tokens = CASE WHEN r.level >= 1 THEN r.level - 1 ELSE r.level END,
+			    updated_at = NOW()
+			FROM refilled r
+			WHERE b.key = r.key
+			RETURNING b.key, b.tokens, r.level >= 1 AS allowed
+		), inserted AS (
+			INSERT INTO gold.rate_limit_buckets (key, tokens, updated_at)
+			SELECT key, burst - 1, NOW()
+			FROM req
+			WHERE key NOT IN (SELECT key FROM refilled)
+			ORDER BY key
+			ON CONFLICT (key) DO NOTHING
+			RETURNING key, tokens, TRUE AS allowed
+		)
+		SELECT key, tokens, allowed FROM updated
+		UNION ALL
+		SELECT key, tokens, allowed FROM inserted
+	`, keys, rates, bursts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	type take struct {
+		tokens  float64
+		allowed bool
+	}
+	taken := make(map[string]take, len(keys))
+	for rows.Next() {
+		var key string
+		var t take
+		if err := rows.Scan(&key, &t.tokens, &t.allowed); err != nil {
+			return nil, nil, err
+		}
+		taken[key] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	tokens := make([]float64, len(keys))
+	allowed := make([]bool, len(keys))
+	for i, key := range keys {
+		t, ok := taken[key]
+		if !ok {
+			// Lost the race to create the bucket
+			t = take{tokens: float64(bursts[i] - 1), allowed: true}
+		}
+		tokens[i], allowed[i] = t.tokens, t.allowed
+	}
+	return tokens, allowed, nil
+}
+
+// PruneRateLimitBuckets removes buckets untouched for longer than idle
+func (r *GoldRepository) PruneRateLimitBuckets(ctx context.Context, idle time.Duration) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM gold.rate_limit_buckets WHERE updated_at < NOW() - make_interval(secs => $1)
+	`, idle.Seconds())
+	return err
+}
+
+// AddClientQuota adds requests to the daily counters of clients. Deltas of deleted
+// clients and IDs that are not client UUIDs are dropped rather than failing the batch.
+func (r *GoldRepository) AddClientQuota(ctx context.Context, deltas []model.ClientQuotaDelta) error {
+	clientIDs := make([]string, len(deltas))
+	days := make([]string, len(deltas))
+	requests := make([]int64, len(deltas))
+	rateLimited := make([]int64, len(deltas))
+	for i, d := range deltas {
+		clientIDs[i], days[i], requests[i], rateLimited[i] = d.ClientID, d.Day, d.Requests, d.RateLimited
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO gold.api_client_quota AS q (client_id, day, requests, rate_limited)
+		SELECT c.id, u.day::date, u.requests, u.rate_limited
+		FROM unnest($1::text[], $2::text[], $3::bigint[], $4::bigint[]) AS u(client_id, day, requests, rate_limited)
+		JOIN gold.api_clients c ON c.id::text = lower(u.client_id)
+		ON CONFLICT (client_id, day) DO UPDATE
+		SET requests = q.requests + EXCLUDED.requests,
+		    rate_limited = q.rate_limited + EXCLUDED.rate_limited
+	`, clientIDs, days, requests, rateLimited)
+	return err
+}
+
+// ListClientQuota returns the daily counters of a client from from up to but
+// excluding to (YYYY-MM-DD), oldest first. Days without requests are omitted.
+func (r *GoldRepository) ListClientQuota(ctx context.Context, clientID, from, to string) ([]model.QuotaUsage, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT to_char(day, 'YYYY-MM-DD'), requests, rate_limited
+		FROM gold.api_client_quota
+		WHERE client_id = $1 AND day >= $2::date AND day < $3::date
+		ORDER BY day
+	`, clientID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usage := []model.QuotaUsage{}
+	for rows.Next() {
+		var u model.QuotaUsage
+		if err := rows.Scan(&u.Period, &u.Requests, &u.RateLimited); err != nil {
+			return nil, err
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+const reconcileReportColumns = `
+	id, source, status, window_from, window_to, repair, started_at, finished_at,
+	silver_count, gold_count, missing_count, extra_count, stale_count, repaired_count, error
+`
+
+// LiveNewsVersion is what reconciliation compares of a live gold row
+type LiveNewsVersion struct {
+	SourceUpdatedAt *time.Time
+	// ContentHash is nil for rows written before hashing or reset by a migration
+	ContentHash *string
+}
+
+// GetLiveNewsBySourceIDs returns the version of live gold rows of a source keyed by source_news_id
+func (r *GoldRepository) GetLiveNewsBySourceIDs(ctx context.Context, source model.NewsSource, sourceNewsIDs []string) (map[string]LiveNewsVersion, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT source_news_id, source_updated_at, content_hash
+		FROM gold.translated_news
+		WHERE source = $1 AND source_news_id = ANY($2) AND deleted_at IS NULL
+	`, string(source), sourceNewsIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	news := make(map[string]LiveNewsVersion)
+	for rows.Next() {
+		var id string
+		var v LiveNewsVersion
+		if err := rows.Scan(&id, &v.SourceUpdatedAt, &v.ContentHash); err != nil {
+			return nil, err
+		}
+		news[id] = v
+	}
+	return news, rows.Err()
+}
+
+// GetLiveNewsUpdatedBetween returns source_updated_at of live gold rows of a source
+// whose source_updated_at is in [from, to), keyed by source_news_id
+func (r *GoldRepository) GetLiveNewsUpdatedBetween(ctx context.Context, source model.NewsSource, from, to time.Time) (map[string]time.Time, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT source_news_id, source_updated_at
+		FROM gold.translated_news
+		WHERE source = $1 AND source_updated_at >= $2 AND source_updated_at < $3 AND deleted_at IS NULL
+	`, string(source), from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	updated := make(map[string]time.Time)
+	for rows.Next() {
+		var id string
+		var updatedAt time.Time
+		if err := rows.Scan(&id, &updatedAt); err != nil {
+			return nil, err
+		}
+		updated[id] = updatedAt
+	}
+	return updated, rows.Err()
+}
+
+// CreateReconcileReport records the start of a reconciliation and returns its ID
+func (r *GoldRepository) CreateReconcileReport(ctx context.Context, report *model.ReconcileReport) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO gold.reconcile_reports (source, status, window_from, window_to, repair, started_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, started_at
+	`, string(report.Source), string(model.ReconcileRunning), report.WindowFrom, report.WindowTo, report.Repair,
+	).Scan(&id, &report.StartedAt)
+	report.Status = model.ReconcileRunning
+	return id, err
+}
+
+// FinishReconcileReport stores the outcome of a reconciliation together with its drift items
+func (r *GoldRepository) FinishReconcileReport(ctx context.Context, report *model.ReconcileReport) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE gold.reconcile_reports
+		SET status = $1, finished_at = NOW(), silver_count = $2, gold_count = $3,
+		    missing_count = $4, extra_count = $5, stale_count = $6, repaired_count = $7, error = $8
+		WHERE id = $9
+	`, string(report.Status), report.SilverCount, report.GoldCount,
+		report.MissingCount, report.ExtraCount, report.StaleCount, report.RepairedCount, report.Error, report.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(report.Drift) > 0 {
+		_, err = tx.CopyFrom(ctx,
+			pgx.Identifier{"gold", "reconcile_drift"},
+			[]string{"report_id", "source_news_id", "kind", "silver_updated_at", "gold_updated_at", "repaired"},
+			pgx.CopyFromSlice(len(report.Drift), func(i int) ([]any, error) {
+				d := report.Drift[i]
+				return []any{report.ID, d.SourceNewsID, string(d.Kind), d.SilverUpdatedAt, d.GoldUpdatedAt, d.Repaired}, nil
+			}),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListReconcileReports returns paginated reconciliation reports without drift items, newest first
+func (r *GoldRepository) ListReconcileReports(ctx context.Context, filter model.ReconcileReportFilter) ([]model.ReconcileReport, int, error) {
+	whereClause := ""
+	var args []interface{}
+	argIdx := 1
+
+	if filter.Source != nil {
+		whereClause = fmt.Sprintf("WHERE source = $%d", argIdx)
+		args = append(args, string(*filter.Source))
+		argIdx++
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM gold.reconcile_reports %s`, whereClause)
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	dataArgs := append(args, filter.Limit, offset)
+	dataQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM gold.reconcile_reports
+		%s
+		ORDER BY started_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, reconcileReportColumns, whereClause, argIdx, argIdx+1)
+
+	rows, err := r.pool.Query(ctx, dataQuery, dataArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	reports := []model.ReconcileReport{}
+	for rows.Next() {
+		report, err := scanReconcileReport(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		reports = append(reports, *report)
+	}
+
+	return reports, total, rows.Err()
+}
+
+// GetReconcileReport returns a reconciliation report with its drift items
+func (r *GoldRepository) GetReconcileReport(ctx context.Context, id int64) (*model.ReconcileReport, error) {
+	row := r.pool.QueryRow(ctx, fmt.Sprintf(`SELECT %s FROM gold.reconcile_reports WHERE id = $1`, reconcileReportColumns), id)
+	report, err := scanReconcileReport(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT source_news_id, kind, silver_updated_at, gold_updated_at, repaired
+		FROM gold.reconcile_drift
+		WHERE report_id = $1
+		ORDER BY kind, source_news_id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d model.DriftItem
+		var kind string
+		if err := rows.Scan(&d.SourceNewsID, &kind, &d.SilverUpdatedAt, &d.GoldUpdatedAt, &d.Repaired); err != nil {
+			return nil, err
+		}
+		d.Kind = model.DriftKind(kind)
+		report.Drift = append(report.Drift, d)
+	}
+
+	return report, rows.Err()
+}
+
+func scanReconcileReport(row pgx.Row) (*model.ReconcileReport, error) {
+	var report model.ReconcileReport
+	var source, status string
+
+	err := row.Scan(
+		&report.ID, &source, &status, &report.WindowFrom, &report.WindowTo, &report.Repair,
+		&report.StartedAt, &report.FinishedAt,
+		&report.SilverCount, &report.GoldCount, &report.MissingCount, &report.ExtraCount,
+		&report.StaleCount, &report.RepairedCount, &report.Error,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Source = model.NewsSource(source)
+	report.Status = model.ReconcileStatus(status)
+	return &report, nil
+}
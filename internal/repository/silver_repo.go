@@ -2,7 +2,7 @@ package repository
 
 import (
 	"context"
-	"time"
+	"fmt"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -18,18 +18,18 @@ func NewSilverRepository(pool *pgxpool.Pool) *SilverRepository {
 	return &SilverRepository{pool: pool}
 }
 
-// GetJPMinkabuNewsSince returns JP Minkabu news updated since the given time
-func (r *SilverRepository) GetJPMinkabuNewsSince(ctx context.Context, since *time.Time, limit int) ([]model.JPMinkabuNews, error) {
+// GetJPMinkabuNewsSince returns JP Minkabu news positioned after the given (updated_at, id) cursor
+func (r *SilverRepository) GetJPMinkabuNewsSince(ctx context.Context, after *model.SyncCursor, limit int) ([]model.JPMinkabuNews, error) {
 	var query string
 	var args []interface{}
 
-	if since == nil {
+	if after == nil {
 		query = `
-			SELECT id, news_id, original_headline, original_story, 
+			SELECT id, news_id, original_headline, original_story,
 			       translated_headline, translated_story, providers, topics, tickers,
 			       creation_time, model_name, created_at, updated_at
 			FROM silver.jp_minkabu_translated_news
-			ORDER BY updated_at ASC
+			ORDER BY updated_at ASC, id ASC
 			LIMIT $1
 		`
 		args = []interface{}{limit}
@@ -39,11 +39,11 @@ func (r *SilverRepository) GetJPMinkabuNewsSince(ctx context.Context, since *tim
 			       translated_headline, translated_story, providers, topics, tickers,
 			       creation_time, model_name, created_at, updated_at
 			FROM silver.jp_minkabu_translated_news
-			WHERE updated_at > $1
-			ORDER BY updated_at ASC
-			LIMIT $2
+			WHERE (updated_at, id) > ($1, $2)
+			ORDER BY updated_at ASC, id ASC
+			LIMIT $3
 		`
-		args = []interface{}{since, limit}
+		args = []interface{}{after.UpdatedAt, after.ID, limit}
 	}
 
 	rows, err := r.pool.Query(ctx, query, args...)
@@ -55,18 +55,18 @@ func (r *SilverRepository) GetJPMinkabuNewsSince(ctx context.Context, since *tim
 	return scanJPMinkabuNews(rows)
 }
 
-// GetCNWindNewsSince returns CN Wind news updated since the given time
-func (r *SilverRepository) GetCNWindNewsSince(ctx context.Context, since *time.Time, limit int) ([]model.CNWindNews, error) {
+// GetCNWindNewsSince returns CN Wind news positioned after the given (updated_at, id) cursor
+func (r *SilverRepository) GetCNWindNewsSince(ctx context.Context, after *model.SyncCursor, limit int) ([]model.CNWindNews, error) {
 	var query string
 	var args []interface{}
 
-	if since == nil {
+	if after == nil {
 		query = `
 			SELECT id, object_id, original_title, original_content,
 			       translated_title, translated_content, publish_date, source,
 			       sections, wind_codes, keywords, model_name, created_at, updated_at
 			FROM silver.cn_wind_translated_news
-			ORDER BY updated_at ASC
+			ORDER BY updated_at ASC, id ASC
 			LIMIT $1
 		`
 		args = []interface{}{limit}
@@ -76,11 +76,11 @@ func (r *SilverRepository) GetCNWindNewsSince(ctx context.Context, since *time.T
 			       translated_title, translated_content, publish_date, source,
 			       sections, wind_codes, keywords, model_name, created_at, updated_at
 			FROM silver.cn_wind_translated_news
-			WHERE updated_at > $1
-			ORDER BY updated_at ASC
-			LIMIT $2
+			WHERE (updated_at, id) > ($1, $2)
+			ORDER BY updated_at ASC, id ASC
+			LIMIT $3
 		`
-		args = []interface{}{since, limit}
+		args = []interface{}{after.UpdatedAt, after.ID, limit}
 	}
 
 	rows, err := r.pool.Query(ctx, query, args...)
@@ -92,6 +92,57 @@ func (r *SilverRepository) GetCNWindNewsSince(ctx context.Context, since *time.T
 	return scanCNWindNews(rows)
 }
 
+// CountJPMinkabuNewsSince counts JP Minkabu news after the cursor, stopping at limit
+func (r *SilverRepository) CountJPMinkabuNewsSince(ctx context.Context, after *model.SyncCursor, limit int) (int, error) {
+	return r.countSince(ctx, "silver.jp_minkabu_translated_news", after, limit)
+}
+
+// CountCNWindNewsSince counts CN Wind news after the cursor, stopping at limit
+func (r *SilverRepository) CountCNWindNewsSince(ctx context.Context, after *model.SyncCursor, limit int) (int, error) {
+	return r.countSince(ctx, "silver.cn_wind_translated_news", after, limit)
+}
+
+// countSince counts at most limit rows of table positioned after the cursor,
+// so estimating a huge backlog stays cheap
+func (r *SilverRepository) countSince(ctx context.Context, table string, after *model.SyncCursor, limit int) (int, error) {
+	var count int
+	var err error
+	if after == nil {
+		err = r.pool.QueryRow(ctx, fmt.Sprintf(`
+			SELECT COUNT(*) FROM (SELECT 1 FROM %s LIMIT $1) t
+		`, table), limit).Scan(&count)
+	} else {
+		err = r.pool.QueryRow(ctx, fmt.Sprintf(`
+			SELECT COUNT(*) FROM (
+				SELECT 1 FROM %s WHERE (updated_at, id) > ($1, $2) LIMIT $3
+			) t
+		`, table), after.UpdatedAt, after.ID, limit).Scan(&count)
+	}
+	return count, err
+}
+
+// GetExistingJPMinkabuNewsIDs returns the subset of newsIDs still present in silver
+func (r *SilverRepository) GetExistingJPMinkabuNewsIDs(ctx context.Context, newsIDs []string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT news_id FROM silver.jp_minkabu_translated_news WHERE news_id = ANY($1)
+	`, newsIDs)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// GetExistingCNWindObjectIDs returns the subset of objectIDs still present in silver
+func (r *SilverRepository) GetExistingCNWindObjectIDs(ctx context.Context, objectIDs []string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT object_id FROM silver.cn_wind_translated_news WHERE object_id = ANY($1)
+	`, objectIDs)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
 func scanJPMinkabuNews(rows pgx.Rows) ([]model.JPMinkabuNews, error) {
 	var news []model.JPMinkabuNews
 	for rows.Next() {
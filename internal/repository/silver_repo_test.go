@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/testdb"
+)
+
+// silverRow is the keyset position and source ID of a fetched silver row
+type silverRow struct {
+	cursor   model.SyncCursor
+	sourceID string
+}
+
+// silverTable describes how to seed and page one silver table
+type silverTable struct {
+	name  string
+	table string
+	// insertSQL inserts source IDs prefix-$2..$3 with updated_at $1
+	insertSQL string
+	fetch     func(ctx context.Context, r *SilverRepository, after *model.SyncCursor, limit int) ([]silverRow, error)
+	count     func(r *SilverRepository, ctx context.Context, after *model.SyncCursor, limit int) (int, error)
+}
+
+var silverTables = []silverTable{
+	{
+		name:  "jp_minkabu",
+		table: "silver.jp_minkabu_translated_news",
+		insertSQL: `
+			INSERT INTO silver.jp_minkabu_translated_news
+				(news_id, original_headline, translated_headline, creation_time, model_name, updated_at)
+			SELECT 'jp-' || g, '見出し', '헤드라인', $1, 'test-model', $1
+			FROM generate_series($2::int, $3::int) g
+		`,
+		fetch: func(ctx context.Context, r *SilverRepository, after *model.SyncCursor, limit int) ([]silverRow, error) {
+			news, err := r.GetJPMinkabuNewsSince(ctx, after, limit)
+			rows := make([]silverRow, len(news))
+			for i, n := range news {
+				rows[i] = silverRow{model.SyncCursor{UpdatedAt: n.UpdatedAt, ID: n.ID}, n.NewsID}
+			}
+			return rows, err
+		},
+		count: (*SilverRepository).CountJPMinkabuNewsSince,
+	},
+	{
+		name:  "cn_wind",
+		table: "silver.cn_wind_translated_news",
+		insertSQL: `
+			INSERT INTO silver.cn_wind_translated_news
+				(object_id, original_title, translated_title, publish_date, model_name, updated_at)
+			SELECT 'cn-' || g, '标题', '헤드라인', $1, 'test-model', $1
+			FROM generate_series($2::int, $3::int) g
+		`,
+		fetch: func(ctx context.Context, r *SilverRepository, after *model.SyncCursor, limit int) ([]silverRow, error) {
+			news, err := r.GetCNWindNewsSince(ctx, after, limit)
+			rows := make([]silverRow, len(news))
+			for i, n := range news {
+				rows[i] = silverRow{model.SyncCursor{UpdatedAt: n.UpdatedAt, ID: n.ID}, n.ObjectID}
+			}
+			return rows, err
+		},
+		count: (*SilverRepository).CountCNWindNewsSince,
+	},
+}
+
+// seed inserts count rows with one updated_at, numbering source IDs from first
+func (tbl silverTable) seed(t *testing.T, pool *pgxpool.Pool, updatedAt time.Time, first, count int) {
+	t.Helper()
+	if _, err := pool.Exec(context.Background(), tbl.insertSQL, updatedAt, first, first+count-1); err != nil {
+		t.Fatalf("failed to seed %s: %v", tbl.table, err)
+	}
+}
+
+// pageAll pages through a table like the sync loop does and returns every row read
+func (tbl silverTable) pageAll(t *testing.T, r *SilverRepository, after *model.SyncCursor, limit int) []silverRow {
+	t.Helper()
+	var all []silverRow
+	for {
+		rows, err := tbl.fetch(context.Background(), r, after, limit)
+		if err != nil {
+			t.Fatalf("fetch after %v: %v", after, err)
+		}
+		if len(rows) == 0 {
+			return all
+		}
+		if len(rows) > limit {
+			t.Fatalf("fetch returned %d rows, limit %d", len(rows), limit)
+		}
+		all = append(all, rows...)
+		last := rows[len(rows)-1].cursor
+		after = &last
+	}
+}
+
+// assertKeysetOrder checks that rows are strictly increasing in (updated_at, id) and
+// that no source ID repeats
+func assertKeysetOrder(t *testing.T, rows []silverRow) {
+	t.Helper()
+	seen := make(map[string]bool, len(rows))
+	for i, row := range rows {
+		if seen[row.sourceID] {
+			t.Fatalf("row %s read twice", row.sourceID)
+		}
+		seen[row.sourceID] = true
+		if i == 0 {
+			continue
+		}
+		prev := rows[i-1].cursor
+		if row.cursor.UpdatedAt.Before(prev.UpdatedAt) ||
+			row.cursor.UpdatedAt.Equal(prev.UpdatedAt) && row.cursor.ID <= prev.ID {
+			t.Fatalf("row %d %v not after %v", i, row.cursor, prev)
+		}
+	}
+}
+
+func TestSilverKeysetNoRowLostAtBatchBoundaries(t *testing.T) {
+	pool := testdb.New(t)
+	r := NewSilverRepository(pool)
+	base := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+
+	for _, tbl := range silverTables {
+		t.Run(tbl.name, func(t *testing.T) {
+			// Later timestamps are inserted first so ids do not follow updated_at, and a
+			// bulk re-translation stamps 1,100 rows with one updated_at across three batches
+			tbl.seed(t, pool, base.Add(2*time.Minute), 1, 131)
+			tbl.seed(t, pool, base, 1000, 3)
+			tbl.seed(t, pool, base.Add(time.Minute), 2000, 1100)
+			tbl.seed(t, pool, base.Add(2*time.Minute), 5000, 7)
+			const total = 131 + 3 + 1100 + 7
+
+			for _, limit := range []int{500, 1100, 1, 7} {
+				rows := tbl.pageAll(t, r, nil, limit)
+				if len(rows) != total {
+					t.Fatalf("limit %d: read %d rows, want %d", limit, len(rows), total)
+				}
+				assertKeysetOrder(t, rows)
+			}
+		})
+	}
+}
+
+func TestSilverKeysetResumesInsideTie(t *testing.T) {
+	pool := testdb.New(t)
+	r := NewSilverRepository(pool)
+	ctx := context.Background()
+	tie := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+
+	for _, tbl := range silverTables {
+		t.Run(tbl.name, func(t *testing.T) {
+			tbl.seed(t, pool, tie, 1, 600)
+
+			// A checkpoint after the first batch points into the middle of the tie
+			first, err := tbl.fetch(ctx, r, nil, 500)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(first) != 500 {
+				t.Fatalf("first batch has %d rows, want 500", len(first))
+			}
+			checkpoint := first[len(first)-1].cursor
+
+			rest := tbl.pageAll(t, r, &checkpoint, 500)
+			if len(rest) != 100 {
+				t.Fatalf("resumed read %d rows, want 100", len(rest))
+			}
+			assertKeysetOrder(t, append(first, rest...))
+
+			pending, err := tbl.count(r, ctx, &checkpoint, 1000)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pending != 100 {
+				t.Errorf("count after checkpoint = %d, want 100", pending)
+			}
+			capped, err := tbl.count(r, ctx, nil, 250)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if capped != 250 {
+				t.Errorf("capped count = %d, want 250", capped)
+			}
+
+			// Cursors from before the id column carry ID 0 and re-read the whole tie once
+			legacy := tbl.pageAll(t, r, &model.SyncCursor{UpdatedAt: tie}, 500)
+			if len(legacy) != 600 {
+				t.Errorf("legacy cursor read %d rows, want 600", len(legacy))
+			}
+			past := tbl.pageAll(t, r, &model.SyncCursor{UpdatedAt: tie, ID: 1 << 62}, 500)
+			if len(past) != 0 {
+				t.Errorf("cursor past the tie read %d rows, want 0", len(past))
+			}
+		})
+	}
+}
+
+func TestSyncCursorRoundTrip(t *testing.T) {
+	pool := testdb.New(t)
+	gold := NewGoldRepository(pool)
+	ctx := context.Background()
+
+	cursor, err := gold.GetSyncCursor(ctx, model.SourceJPMinkabu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cursor != nil {
+		t.Fatalf("cursor before first sync = %v, want nil", cursor)
+	}
+
+	want := model.SyncCursor{UpdatedAt: time.Date(2026, 1, 29, 10, 20, 0, 123456000, time.UTC), ID: 9007199254740993}
+	if err := gold.ResetSyncCursor(ctx, model.SourceJPMinkabu, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := gold.GetSyncCursor(ctx, model.SourceJPMinkabu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || !got.UpdatedAt.Equal(want.UpdatedAt) || got.ID != want.ID {
+		t.Fatalf("cursor = %v, want %v", got, want)
+	}
+	if other, err := gold.GetSyncCursor(ctx, model.SourceCNWind); err != nil || other != nil {
+		t.Fatalf("other source cursor = %v, %v; want nil", other, err)
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/onelineai/hana-news-api/internal/auth"
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// statsWhere builds the conditions shared by the aggregations over live news,
+// limited to the sources the caller is licensed for
+func statsWhere(ctx context.Context, source *model.NewsSource, from, to time.Time) (string, []interface{}, int) {
+	where := "deleted_at IS NULL AND published_at >= $1 AND published_at < $2"
+	args := []interface{}{from, to}
+	argIdx := 3
+	if source != nil {
+		where += fmt.Sprintf(" AND source = $%d", argIdx)
+		args = append(args, string(*source))
+		argIdx++
+	}
+	if allowed := auth.AllowedSources(ctx); allowed != nil {
+		where += fmt.Sprintf(" AND source = ANY($%d::text[])", argIdx)
+		args = append(args, sourceStrings(allowed))
+		argIdx++
+	}
+	return where, args, argIdx
+}
+
+// CountTickers returns the most mentioned tickers in a window with their company names
+func (r *GoldRepository) CountTickers(ctx context.Context, filter model.StatsFilter) ([]model.TickerCount, error) {
+	where, args, argIdx := statsWhere(ctx, filter.Source, filter.From, filter.To)
+	query := fmt.Sprintf(`
+		SELECT c.ticker, c.cnt, i.name_ko, i.name_ja, i.name_zh
+		FROM (
+			SELECT t AS ticker, COUNT(*) AS cnt
+			FROM gold.translated_news, unnest(tickers) AS t
+			WHERE %s
+			GROUP BY t
+			ORDER BY cnt DESC, t
+			LIMIT $%d
+		) c
+		LEFT JOIN gold.instruments i ON i.ticker = c.ticker
+		ORDER BY c.cnt DESC, c.ticker
+	`, where, argIdx)
+
+	rows, err := r.pool.Query(ctx, query, append(args, filter.Limit)...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.TickerCount, error) {
+		var c model.TickerCount
+		err := row.Scan(&c.Ticker, &c.Count, &c.NameKO, &c.NameJA, &c.NameZH)
+		return c, err
+	})
+}
+
+// CountTopics returns the most used topics in a window
+func (r *GoldRepository) CountTopics(ctx context.Context, filter model.StatsFilter) ([]model.TopicCount, error) {
+	where, args, argIdx := statsWhere(ctx, filter.Source, filter.From, filter.To)
+	query := fmt.Sprintf(`
+		SELECT t, COUNT(*) AS cnt
+		FROM gold.translated_news, unnest(topics) AS t
+		WHERE %s
+		GROUP BY t
+		ORDER BY cnt DESC, t
+		LIMIT $%d
+	`, where, argIdx)
+
+	rows, err := r.pool.Query(ctx, query, append(args, filter.Limit)...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.TopicCount, error) {
+		var c model.TopicCount
+		err := row.Scan(&c.Topic, &c.Count)
+		return c, err
+	})
+}
+
+// TimezoneExists reports whether Postgres knows a time zone name, which may differ
+// from the zones in Go's tzdata
+func (r *GoldRepository) TimezoneExists(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM pg_timezone_names WHERE name = $1)`, name).Scan(&exists)
+	return exists, err
+}
+
+// CountTickerNewsByBucket returns news counts of a ticker keyed by bucket start (Unix
+// seconds). Empty buckets are absent; the service fills them in.
+func (r *GoldRepository) CountTickerNewsByBucket(ctx context.Context, filter model.HistogramFilter) (map[int64]int, error) {
+	where, args, argIdx := statsWhere(ctx, filter.Source, filter.From, filter.To)
+	// date_trunc on the local wall clock aligns buckets to the requested zone;
+	// @> keeps the tickers GIN index usable
+	query := fmt.Sprintf(`
+		SELECT date_trunc($%d, published_at AT TIME ZONE $%d) AS bucket, COUNT(*)
+		FROM gold.translated_news
+		WHERE %s AND tickers @> ARRAY[$%d::text]
+		GROUP BY bucket
+	`, argIdx, argIdx+1, where, argIdx+2)
+	args = append(args, string(filter.Interval), filter.Location.String(), filter.Ticker)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]int)
+	for rows.Next() {
+		var local time.Time
+		var count int
+		if err := rows.Scan(&local, &count); err != nil {
+			return nil, err
+		}
+		// timestamp without time zone comes back as UTC; reinterpret the wall clock
+		start := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, filter.Location)
+		counts[start.Unix()] = count
+	}
+	return counts, rows.Err()
+}
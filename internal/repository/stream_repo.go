@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+const (
+	// NewsSyncedChannel is notified whenever news writes commit
+	NewsSyncedChannel = "gold_news_synced"
+
+	// newsSeqLockKey is the advisory lock serializing sync_seq assignment. Only the
+	// short assignment step holds it, not the upserts.
+	newsSeqLockKey = 0x6e657773 // "news"
+
+	lockNewsSeqSQL      = `SELECT pg_advisory_xact_lock($1)`
+	notifyNewsSyncedSQL = `SELECT pg_notify('` + NewsSyncedChannel + `', '')`
+)
+
+// AssignNewsSeq gives committed rows written without a sync sequence the next values
+// and notifies stream listeners. Upserts call it after they commit. Assignments are
+// serialized, so sequence order equals commit order and readers never skip a late
+// commit. Rows still locked by an uncommitted upsert are left to that upsert's own
+// assignment.
+func (r *GoldRepository) AssignNewsSeq(ctx context.Context) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, lockNewsSeqSQL, newsSeqLockKey); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `
+		UPDATE gold.translated_news n
+		SET sync_seq = nextval('gold.news_sync_seq')
+		FROM (
+			SELECT id FROM gold.translated_news
+			WHERE sync_seq IS NULL
+			ORDER BY synced_at, id
+			FOR UPDATE SKIP LOCKED
+		) p
+		WHERE n.id = p.id
+	`)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() > 0 {
+		if _, err := tx.Exec(ctx, notifyNewsSyncedSQL); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// GetLatestNewsSeq returns the highest committed sync sequence
+func (r *GoldRepository) GetLatestNewsSeq(ctx context.Context) (int64, error) {
+	var seq int64
+	err := r.pool.QueryRow(ctx, `SELECT COALESCE(MAX(sync_seq), 0) FROM gold.translated_news`).Scan(&seq)
+	return seq, err
+}
+
+// ListNewsSince returns live news written after the given sync sequence, oldest first
+func (r *GoldRepository) ListNewsSince(ctx context.Context, afterSeq int64, filter model.NewsFilter, limit int) ([]model.NewsEvent, error) {
+	q := buildNewsQuery(filter)
+
+	matchedExpr := "NULL::text[]"
+	if q.tickerArg > 0 {
+		matchedExpr = fmt.Sprintf("ARRAY(SELECT t FROM unnest(tickers) AS t WHERE t = ANY($%d::text[]))", q.tickerArg)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT sync_seq, source, tickers, id, translated_headline, translated_content,
+			published_at, provider, %s AS matched_tickers
+		FROM gold.translated_news
+		%s AND sync_seq > $%d
+		ORDER BY sync_seq
+		LIMIT $%d
+	`, matchedExpr, q.where, q.argIdx, q.argIdx+1)
+
+	rows, err := r.pool.Query(ctx, query, append(q.args, afterSeq, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []model.NewsEvent
+	for rows.Next() {
+		var e model.NewsEvent
+		var source string
+		var publishedAt time.Time
+		if err := rows.Scan(&e.Seq, &source, &e.Tickers, &e.Item.ID, &e.Item.Headline, &e.Item.Content,
+			&publishedAt, &e.Item.Publisher, &e.Item.MatchedTickers); err != nil {
+			return nil, err
+		}
+		e.Source = model.NewsSource(source)
+		e.Item.Date = publishedAt.Format("2006.01.02")
+		e.Item.Time = publishedAt.Format("15:04")
+		e.Item.PublishedAt = publishedAt
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// ListenNewsSynced calls notify for every news write notification until ctx is
+// done or the listening connection fails
+func (r *GoldRepository) ListenNewsSynced(ctx context.Context, notify func()) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// LISTEN state must not leak back into the pool
+		conn.Hijack().Close(context.WithoutCancel(ctx))
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+NewsSyncedChannel); err != nil {
+		return err
+	}
+	for {
+		if _, err := conn.Conn().WaitForNotification(ctx); err != nil {
+			return err
+		}
+		notify()
+	}
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/testdb"
+)
+
+func TestNewsSeqFollowsCommitOrder(t *testing.T) {
+	pool := testdb.New(t)
+	gold := NewGoldRepository(pool)
+	ctx := context.Background()
+	at := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+
+	seqOf := func(id string) *int64 {
+		t.Helper()
+		var seq *int64
+		err := pool.QueryRow(ctx, `SELECT sync_seq FROM gold.translated_news WHERE source_news_id = $1`, id).Scan(&seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return seq
+	}
+
+	// A slow writer keeps its transaction open
+	slow, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer slow.Rollback(ctx)
+	if _, err := upsertNews(ctx, slow, testNews(1, 1, at)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another writer is not blocked by it and its rows are numbered on commit
+	timeout, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	cursor := model.SyncCursor{UpdatedAt: at, ID: 1}
+	if _, err := gold.UpsertNewsWithCursor(timeout, model.SourceJPMinkabu, testNews(2, 1, at), cursor, 0); err != nil {
+		t.Fatalf("second writer: %v", err)
+	}
+	fast := seqOf("jp-2")
+	if fast == nil {
+		t.Fatal("committed row has no sync_seq")
+	}
+
+	if err := slow.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if seq := seqOf("jp-1"); seq != nil {
+		t.Fatalf("row has sync_seq %d before assignment", *seq)
+	}
+	if err := gold.AssignNewsSeq(ctx); err != nil {
+		t.Fatal(err)
+	}
+	late := seqOf("jp-1")
+	if late == nil || *late <= *fast {
+		t.Fatalf("late commit got sync_seq %v, want after %d", late, *fast)
+	}
+
+	// Readers resuming after the first commit still see the late one
+	events, err := gold.ListNewsSince(ctx, *fast, model.NewsFilter{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Seq != *late {
+		t.Fatalf("events after %d = %+v, want the late commit", *fast, events)
+	}
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+const syncRunColumns = `
+	id, source, status, trigger, started_at, finished_at, rows_fetched,
+	rows_inserted, rows_updated, rows_unchanged, rows_duplicate,
+	cursor_before_at, cursor_before_id, cursor_after_at, cursor_after_id, error
+`
+
+// CreateSyncRun records the start of a sync run and returns its ID
+func (r *GoldRepository) CreateSyncRun(ctx context.Context, run *model.SyncRun) (int64, error) {
+	var beforeAt *time.Time
+	var beforeID *int64
+	if run.CursorBefore != nil {
+		beforeAt, beforeID = &run.CursorBefore.UpdatedAt, &run.CursorBefore.ID
+	}
+
+	var id int64
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO gold.sync_runs (source, status, trigger, started_at, cursor_before_at, cursor_before_id)
+		VALUES ($1, $2, $3, NOW(), $4, $5)
+		RETURNING id, started_at
+	`, string(run.Source), string(model.SyncRunRunning), string(run.Trigger), beforeAt, beforeID).Scan(&id, &run.StartedAt)
+	run.Status = model.SyncRunRunning
+	return id, err
+}
+
+// FinishSyncRun records the outcome of a sync run
+func (r *GoldRepository) FinishSyncRun(ctx context.Context, run *model.SyncRun) error {
+	var afterAt *time.Time
+	var afterID *int64
+	if run.CursorAfter != nil {
+		afterAt, afterID = &run.CursorAfter.UpdatedAt, &run.CursorAfter.ID
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE gold.sync_runs
+		SET status = $1, finished_at = NOW(), rows_fetched = $2, rows_upserted = $3,
+		    rows_inserted = $4, rows_updated = $5, rows_unchanged = $6, rows_duplicate = $7,
+		    cursor_after_at = $8, cursor_after_id = $9, error = $10
+		WHERE id = $11
+	`, string(run.Status), run.RowsFetched, run.Written(),
+		run.Inserted, run.Updated, run.Unchanged, run.Duplicate,
+		afterAt, afterID, run.Error, run.ID)
+	return err
+}
+
+// ListSyncRuns returns paginated sync runs, newest first
+func (r *GoldRepository) ListSyncRuns(ctx context.Context, filter model.SyncRunFilter) ([]model.SyncRun, int, error) {
+	var conditions []string
+	var args []interface{}
+	argIdx := 1
+
+	if filter.Source != nil {
+		conditions = append(conditions, fmt.Sprintf("source = $%d", argIdx))
+		args = append(args, string(*filter.Source))
+		argIdx++
+	}
+
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIdx))
+		args = append(args, string(*filter.Status))
+		argIdx++
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM gold.sync_runs %s`, whereClause)
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	dataArgs := append(args, filter.Limit, offset)
+	dataQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM gold.sync_runs
+		%s
+		ORDER BY started_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, syncRunColumns, whereClause, argIdx, argIdx+1)
+
+	rows, err := r.pool.Query(ctx, dataQuery, dataArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	runs := []model.SyncRun{}
+	for rows.Next() {
+		run, err := scanSyncRun(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		runs = append(runs, *run)
+	}
+
+	return runs, total, rows.Err()
+}
+
+// GetSyncRun returns a sync run by ID
+func (r *GoldRepository) GetSyncRun(ctx context.Context, id int64) (*model.SyncRun, error) {
+	row := r.pool.QueryRow(ctx, fmt.Sprintf(`SELECT %s FROM gold.sync_runs WHERE id = $1`, syncRunColumns), id)
+	run, err := scanSyncRun(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return run, err
+}
+
+func scanSyncRun(row pgx.Row) (*model.SyncRun, error) {
+	var run model.SyncRun
+	var source, status, trigger string
+	var beforeAt, afterAt *time.Time
+	var beforeID, afterID *int64
+
+	err := row.Scan(
+		&run.ID, &source, &status, &trigger, &run.StartedAt, &run.FinishedAt, &run.RowsFetched,
+		&run.Inserted, &run.Updated, &run.Unchanged, &run.Duplicate,
+		&beforeAt, &beforeID, &afterAt, &afterID, &run.Error,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	run.Source = model.NewsSource(source)
+	run.Status = model.SyncRunStatus(status)
+	run.Trigger = model.SyncTrigger(trigger)
+	run.CursorBefore = toSyncCursor(beforeAt, beforeID)
+	run.CursorAfter = toSyncCursor(afterAt, afterID)
+	return &run, nil
+}
+
+func toSyncCursor(at *time.Time, id *int64) *model.SyncCursor {
+	if at == nil {
+		return nil
+	}
+	cursor := &model.SyncCursor{UpdatedAt: *at}
+	if id != nil {
+		cursor.ID = *id
+	}
+	return cursor
+}
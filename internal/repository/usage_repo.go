@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// InsertUsageEvents stores metered requests and returns how many were stored. Events
+// are COPYed into a staging table and merged with one INSERT ... SELECT that skips
+// events of clients that no longer exist, so one such event cannot roll back the rest.
+func (r *GoldRepository) InsertUsageEvents(ctx context.Context, events []model.UsageEvent) (int64, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		CREATE TEMP TABLE usage_staging (
+			client_id    TEXT,
+			endpoint     TEXT,
+			status       INTEGER,
+			result_count INTEGER,
+			news_ids     TEXT[],
+			created_at   TIMESTAMPTZ
+		) ON COMMIT DROP
+	`)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"usage_staging"},
+		[]string{"client_id", "endpoint", "status", "result_count", "news_ids", "created_at"},
+		pgx.CopyFromSlice(len(events), func(i int) ([]any, error) {
+			e := events[i]
+			newsIDs := e.NewsIDs
+			if newsIDs == nil {
+				newsIDs = []string{}
+			}
+			return []any{e.ClientID, e.Endpoint, e.Status, e.ResultCount, newsIDs, e.CreatedAt}, nil
+		}),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// Comparing as text keeps IDs that are not UUIDs from failing the cast
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO gold.usage_events (client_id, endpoint, status, result_count, news_ids, created_at)
+		SELECT c.id, s.endpoint, s.status, s.result_count, s.news_ids::uuid[], s.created_at
+		FROM usage_staging s
+		JOIN gold.api_clients c ON c.id::text = lower(s.client_id)
+	`)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// UsageReport totals usage events created in [from, to) per client and endpoint,
+// optionally for one client
+func (r *GoldRepository) UsageReport(ctx context.Context, from, to time.Time, clientID *string) ([]model.UsageReportRow, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH e AS (
+			SELECT client_id, endpoint, status, result_count, news_ids
+			FROM gold.usage_events
+			WHERE created_at >= $1 AND created_at < $2 AND ($3::uuid IS NULL OR client_id = $3::uuid)
+		), viewed AS (
+			SELECT e.client_id, e.endpoint, COUNT(DISTINCT v.news_id) AS unique_articles
+			FROM e, unnest(e.news_ids) AS v(news_id)
+			GROUP BY e.client_id, e.endpoint
+		)
+		SELECT e.client_id, c.name, e.endpoint, COUNT(*),
+		       COUNT(*) FILTER (WHERE e.status >= 400),
+		       COALESCE(SUM(e.result_count), 0),
+		       COALESCE(MAX(v.unique_articles), 0)
+		FROM e
+		JOIN gold.api_clients c ON c.id = e.client_id
+		LEFT JOIN viewed v ON v.client_id = e.client_id AND v.endpoint = e.endpoint
+		GROUP BY e.client_id, c.name, e.endpoint
+		ORDER BY c.name, e.client_id, e.endpoint
+	`, from, to, clientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := []model.UsageReportRow{}
+	for rows.Next() {
+		var row model.UsageReportRow
+		if err := rows.Scan(&row.ClientID, &row.ClientName, &row.Endpoint, &row.Requests,
+			&row.Errors, &row.Articles, &row.UniqueArticles); err != nil {
+			return nil, err
+		}
+		report = append(report, row)
+	}
+	return report, rows.Err()
+}
+
+// UsageArticles counts the detail views per client and article in [from, to),
+// optionally for one client
+func (r *GoldRepository) UsageArticles(ctx context.Context, from, to time.Time, clientID *string) ([]model.UsageArticleRow, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT e.client_id, c.name, v.news_id, COUNT(*), MIN(e.created_at), MAX(e.created_at)
+		FROM gold.usage_events e
+		CROSS JOIN LATERAL unnest(e.news_ids) AS v(news_id)
+		JOIN gold.api_clients c ON c.id = e.client_id
+		WHERE e.created_at >= $1 AND e.created_at < $2 AND ($3::uuid IS NULL OR e.client_id = $3::uuid)
+		GROUP BY e.client_id, c.name, v.news_id
+		ORDER BY c.name, e.client_id, MIN(e.created_at), v.news_id
+	`, from, to, clientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := []model.UsageArticleRow{}
+	for rows.Next() {
+		var row model.UsageArticleRow
+		if err := rows.Scan(&row.ClientID, &row.ClientName, &row.NewsID, &row.Views,
+			&row.FirstViewedAt, &row.LastViewedAt); err != nil {
+			return nil, err
+		}
+		report = append(report, row)
+	}
+	return report, rows.Err()
+}
@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+const webhookColumns = `id, url, description, source, tickers, topics, active, created_at, updated_at`
+
+// CreateWebhook stores a new webhook. It only receives news synced from now on.
+func (r *GoldRepository) CreateWebhook(ctx context.Context, w *model.Webhook) error {
+	return r.pool.QueryRow(ctx, `
+		INSERT INTO gold.webhook_subscriptions (url, secret, description, source, tickers, topics, active, last_seq)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT COALESCE(MAX(sync_seq), 0) FROM gold.translated_news))
+		RETURNING id, created_at, updated_at
+	`, w.URL, w.Secret, w.Description, webhookSource(w), w.Tickers, w.Topics, w.Active,
+	).Scan(&w.ID, &w.CreatedAt, &w.UpdatedAt)
+}
+
+// UpdateWebhook replaces a webhook's settings, keeping its secret if secret is empty.
+// A reactivated webhook skips news synced while it was inactive. Returns false if
+// the webhook does not exist.
+func (r *GoldRepository) UpdateWebhook(ctx context.Context, w *model.Webhook, secret string) (bool, error) {
+	err := r.pool.QueryRow(ctx, `
+		UPDATE gold.webhook_subscriptions
+		SET url = $2, secret = COALESCE(NULLIF($3, ''), secret), description = $4,
+		    source = $5, tickers = $6, topics = $7, active = $8,
+		    last_seq = CASE WHEN NOT active AND $8
+		                    THEN (SELECT COALESCE(MAX(sync_seq), 0) FROM gold.translated_news)
+		                    ELSE last_seq END,
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`, w.ID, w.URL, secret, w.Description, webhookSource(w), w.Tickers, w.Topics, w.Active,
+	).Scan(&w.CreatedAt, &w.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ListPlaintextWebhookSecrets returns the secrets, by webhook ID, that do not start
+// with the prefix of encrypted secrets
+func (r *GoldRepository) ListPlaintextWebhookSecrets(ctx context.Context, sealedPrefix string) (map[string]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, secret FROM gold.webhook_subscriptions WHERE NOT starts_with(secret, $1)
+	`, sealedPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	secrets := map[string]string{}
+	for rows.Next() {
+		var id, secret string
+		if err := rows.Scan(&id, &secret); err != nil {
+			return nil, err
+		}
+		secrets[id] = secret
+	}
+	return secrets, rows.Err()
+}
+
+// ReplaceWebhookSecret swaps a webhook's stored secret unless it was changed since it was read
+func (r *GoldRepository) ReplaceWebhookSecret(ctx context.Context, id, old, secret string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE gold.webhook_subscriptions SET secret = $3 WHERE id = $1 AND secret = $2
+	`, id, old, secret)
+	return err
+}
+
+// DeleteWebhook removes a webhook with its pending deliveries and dead letters.
+// Returns false if the webhook does not exist.
+func (r *GoldRepository) DeleteWebhook(ctx context.Context, id string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM gold.webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetWebhook returns a webhook by ID, or nil if it does not exist
+func (r *GoldRepository) GetWebhook(ctx context.Context, id string) (*model.Webhook, error) {
+	row := r.pool.QueryRow(ctx, fmt.Sprintf(`SELECT %s FROM gold.webhook_subscriptions WHERE id = $1`, webhookColumns), id)
+	w, err := scanWebhook(row)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	return w, err
+}
+
+// ListWebhooks returns paginated webhooks, oldest first
+func (r *GoldRepository) ListWebhooks(ctx context.Context, filter model.WebhookFilter) ([]model.Webhook, int, error) {
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM gold.webhook_subscriptions`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT %s
+		FROM gold.webhook_subscriptions
+		ORDER BY created_at, id
+		LIMIT $1 OFFSET $2
+	`, webhookColumns), filter.Limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	webhooks := []model.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		webhooks = append(webhooks, *w)
+	}
+	return webhooks, total, rows.Err()
+}
+
+// ListActiveWebhooks returns every active webhook
+func (r *GoldRepository) ListActiveWebhooks(ctx context.Context) ([]model.Webhook, error) {
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT %s FROM gold.webhook_subscriptions WHERE active ORDER BY created_at, id
+	`, webhookColumns))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []model.Webhook
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *w)
+	}
+	return webhooks, rows.Err()
+}
+
+// EnqueueWebhookDeliveries queues news matching the webhook that were written after
+// its last queued sequence, up to and including upToSeq, and advances the webhook.
+// A webhook being queued by another replica is skipped.
+func (r *GoldRepository) EnqueueWebhookDeliveries(ctx context.Context, w model.Webhook, upToSeq int64) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var lastSeq int64
+	err = tx.QueryRow(ctx, `
+		SELECT last_seq FROM gold.webhook_subscriptions
+		WHERE id = $1 AND active
+		FOR UPDATE SKIP LOCKED
+	`, w.ID).Scan(&lastSeq)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if lastSeq >= upToSeq {
+		return 0, nil
+	}
+
+	q := buildNewsQuery(w.NewsFilter())
+	query := fmt.Sprintf(`
+		INSERT INTO gold.webhook_deliveries (subscription_id, news_id, event)
+		SELECT $%d::uuid, id, $%d
+		FROM gold.translated_news
+		%s AND sync_seq > $%d AND sync_seq <= $%d
+		ORDER BY sync_seq
+	`, q.argIdx, q.argIdx+1, q.where, q.argIdx+2, q.argIdx+3)
+	tag, err := tx.Exec(ctx, query, append(q.args, w.ID, string(model.WebhookEventSynced), lastSeq, upToSeq)...)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE gold.webhook_subscriptions SET last_seq = $2 WHERE id = $1
+	`, w.ID, upToSeq); err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), tx.Commit(ctx)
+}
+
+// EnqueueWebhookReplay queues live news matching the webhook published between from and to
+func (r *GoldRepository) EnqueueWebhookReplay(ctx context.Context, w model.Webhook, from, to time.Time) (int, error) {
+	filter := w.NewsFilter()
+	filter.From, filter.To = &from, &to
+
+	q := buildNewsQuery(filter)
+	query := fmt.Sprintf(`
+		INSERT INTO gold.webhook_deliveries (subscription_id, news_id, event)
+		SELECT $%d::uuid, id, $%d
+		FROM gold.translated_news
+		%s
+		ORDER BY published_at, id
+	`, q.argIdx, q.argIdx+1, q.where)
+	tag, err := r.pool.Exec(ctx, query, append(q.args, w.ID, string(model.WebhookEventReplay))...)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ClaimWebhookDeliveries locks up to limit due deliveries of active webhooks for the
+// lease duration and counts the attempt. Deliveries not completed, retried or
+// dead-lettered before the lease ends are claimed again.
+func (r *GoldRepository) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH claimed AS (
+			UPDATE gold.webhook_deliveries d
+			SET attempts = d.attempts + 1, next_attempt_at = NOW() + make_interval(secs => $2)
+			WHERE d.id IN (
+				SELECT q.id
+				FROM gold.webhook_deliveries q
+				JOIN gold.webhook_subscriptions s ON s.id = q.subscription_id AND s.active
+				WHERE q.next_attempt_at <= NOW()
+				ORDER BY q.next_attempt_at, q.id
+				LIMIT $1
+				FOR UPDATE OF q SKIP LOCKED
+			)
+			RETURNING d.id, d.subscription_id, d.news_id, d.event, d.attempts, d.created_at
+		)
+		SELECT c.id, c.event, c.attempts, c.created_at,
+		       s.id, s.url, s.secret, s.tickers,
+		       n.sync_seq, n.source, n.tickers, n.id, n.translated_headline, n.translated_content,
+		       n.published_at, n.provider
+		FROM claimed c
+		JOIN gold.webhook_subscriptions s ON s.id = c.subscription_id
+		LEFT JOIN gold.translated_news n ON n.id = c.news_id AND n.deleted_at IS NULL
+		ORDER BY c.id
+	`, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		var d model.WebhookDelivery
+		var event string
+		var seq *int64
+		var source, newsID, headline *string
+		var tickers []string
+		var content, provider *string
+		var publishedAt *time.Time
+		if err := rows.Scan(&d.ID, &event, &d.Attempts, &d.CreatedAt,
+			&d.Webhook.ID, &d.Webhook.URL, &d.Webhook.Secret, &d.Webhook.Tickers,
+			&seq, &source, &tickers, &newsID, &headline, &content,
+			&publishedAt, &provider); err != nil {
+			return nil, err
+		}
+		d.Event = model.WebhookEvent(event)
+		// A row rewritten since it was queued has no sequence yet and is queued again
+		if newsID != nil && seq != nil {
+			e := &model.NewsEvent{Seq: *seq, Source: model.NewsSource(*source), Tickers: tickers}
+			e.Item.ID = *newsID
+			e.Item.Headline = *headline
+			e.Item.Content = content
+			e.Item.Publisher = provider
+			e.Item.Date = publishedAt.Format("2006.01.02")
+			e.Item.Time = publishedAt.Format("15:04")
+			e.Item.PublishedAt = *publishedAt
+			d.News = e
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// CompleteWebhookDelivery removes a delivered or obsolete delivery
+func (r *GoldRepository) CompleteWebhookDelivery(ctx context.Context, id int64) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM gold.webhook_deliveries WHERE id = $1`, id)
+	return err
+}
+
+// RetryWebhookDelivery records a failed attempt and schedules the next one
+func (r *GoldRepository) RetryWebhookDelivery(ctx context.Context, id int64, next time.Time, status *int, errMsg string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE gold.webhook_deliveries
+		SET next_attempt_at = $2, last_status = $3, last_error = $4
+		WHERE id = $1
+	`, id, next, status, errMsg)
+	return err
+}
+
+// DeadLetterWebhookDelivery moves a delivery that failed its last attempt to the dead letters
+func (r *GoldRepository) DeadLetterWebhookDelivery(ctx context.Context, id int64, status *int, errMsg string) error {
+	_, err := r.pool.Exec(ctx, `
+		WITH moved AS (
+			DELETE FROM gold.webhook_deliveries WHERE id = $1
+			RETURNING id, subscription_id, news_id, event, attempts, created_at
+		)
+		INSERT INTO gold.webhook_dead_letters
+			(id, subscription_id, news_id, event, attempts, last_status, last_error, created_at)
+		SELECT id, subscription_id, news_id, event, attempts, $2::int, $3::text, created_at
+		FROM moved
+		ON CONFLICT (id) DO NOTHING
+	`, id, status, errMsg)
+	return err
+}
+
+// ListWebhookDeadLetters returns paginated dead letters of a webhook, newest first
+func (r *GoldRepository) ListWebhookDeadLetters(ctx context.Context, filter model.WebhookDeadLetterFilter) ([]model.WebhookDeadLetter, int, error) {
+	var total int
+	if err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM gold.webhook_dead_letters WHERE subscription_id = $1`, filter.WebhookID,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, subscription_id, news_id, event, attempts, last_status, last_error, created_at, failed_at
+		FROM gold.webhook_dead_letters
+		WHERE subscription_id = $1
+		ORDER BY failed_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, filter.WebhookID, filter.Limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	letters := []model.WebhookDeadLetter{}
+	for rows.Next() {
+		var l model.WebhookDeadLetter
+		var event string
+		if err := rows.Scan(&l.ID, &l.WebhookID, &l.NewsID, &event, &l.Attempts,
+			&l.LastStatus, &l.LastError, &l.CreatedAt, &l.FailedAt); err != nil {
+			return nil, 0, err
+		}
+		l.Event = model.WebhookEvent(event)
+		letters = append(letters, l)
+	}
+	return letters, total, rows.Err()
+}
+
+func scanWebhook(row pgx.Row) (*model.Webhook, error) {
+	var w model.Webhook
+	var source *string
+	if err := row.Scan(&w.ID, &w.URL, &w.Description, &source, &w.Tickers, &w.Topics,
+		&w.Active, &w.CreatedAt, &w.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if source != nil {
+		country := model.NewsSource(*source).Country()
+		w.Country = &country
+	}
+	return &w, nil
+}
+
+// webhookSource returns the news source column value of a webhook
+func webhookSource(w *model.Webhook) *string {
+	if w.Country == nil {
+		return nil
+	}
+	source := string(w.Country.ToNewsSource())
+	return &source
+}
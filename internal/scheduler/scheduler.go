@@ -3,36 +3,53 @@ package scheduler
 import (
 	"context"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/go-co-op/gocron/v2"
+	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/model"
 	"github.com/onelineai/hana-news-api/internal/service"
 )
 
 // Scheduler manages background batch jobs
 type Scheduler struct {
-	scheduler    gocron.Scheduler
-	batchService *service.BatchService
-	logger       *slog.Logger
-	interval     time.Duration
+	scheduler          gocron.Scheduler
+	batchService       *service.BatchService
+	retractionService  *service.RetractionService
+	reconcileService   *service.ReconcileService
+	logger             *slog.Logger
+	interval           time.Duration
+	retractionInterval time.Duration
+	reconcile          config.ReconcileConfig
+
+	// ctx outlives the HTTP request that triggers a manual sync
+	ctx    context.Context
+	manual sync.WaitGroup
 }
 
-func New(batchService *service.BatchService, interval time.Duration, logger *slog.Logger) (*Scheduler, error) {
+func New(batchService *service.BatchService, retractionService *service.RetractionService, reconcileService *service.ReconcileService, cfg *config.Config, logger *slog.Logger) (*Scheduler, error) {
 	s, err := gocron.NewScheduler()
 	if err != nil {
 		return nil, err
 	}
 
 	return &Scheduler{
-		scheduler:    s,
-		batchService: batchService,
-		logger:       logger,
-		interval:     interval,
+		scheduler:          s,
+		batchService:       batchService,
+		retractionService:  retractionService,
+		reconcileService:   reconcileService,
+		logger:             logger,
+		interval:           cfg.Batch.Interval,
+		retractionInterval: cfg.Batch.RetractionInterval,
+		reconcile:          cfg.Reconcile,
 	}, nil
 }
 
 // Start begins the scheduler
 func (s *Scheduler) Start(ctx context.Context) error {
+	s.ctx = ctx
+
 	// Define the batch sync job
 	_, err := s.scheduler.NewJob(
 		gocron.DurationJob(s.interval),
@@ -44,6 +61,32 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		return err
 	}
 
+	// Define the retraction sweep job (disabled when interval is 0)
+	if s.retractionInterval > 0 {
+		_, err = s.scheduler.NewJob(
+			gocron.DurationJob(s.retractionInterval),
+			gocron.NewTask(s.runRetractionSweep, ctx),
+			gocron.WithSingletonMode(gocron.LimitModeReschedule),
+			gocron.WithName("retraction-sweep"),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Define the reconciliation job (disabled when interval is 0)
+	if s.reconcile.Interval > 0 {
+		_, err = s.scheduler.NewJob(
+			gocron.DurationJob(s.reconcile.Interval),
+			gocron.NewTask(s.runReconcile, ctx),
+			gocron.WithSingletonMode(gocron.LimitModeReschedule),
+			gocron.WithName("reconcile"),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Run initial sync immediately
 	go func() {
 		s.logger.Info("running initial batch sync")
@@ -60,7 +103,50 @@ func (s *Scheduler) Start(ctx context.Context) error {
 // Stop gracefully stops the scheduler
 func (s *Scheduler) Stop() error {
 	s.logger.Info("stopping scheduler")
-	return s.scheduler.Shutdown()
+	err := s.scheduler.Shutdown()
+	s.manual.Wait()
+	return err
+}
+
+// Trigger starts a sync outside the schedule and returns its runs without waiting for it.
+// It fails with service.ErrSyncInProgress if the scheduled job or another manual sync is running.
+func (s *Scheduler) Trigger(req service.SyncRequest) ([]*model.SyncRun, error) {
+	runs, run, err := s.batchService.StartSync(s.ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.manual.Add(1)
+	go func() {
+		defer s.manual.Done()
+		s.logger.Info("manual batch sync triggered", "trigger", req.Trigger)
+		if err := run(); err != nil {
+			s.logger.Error("manual batch sync failed", "error", err)
+		}
+	}()
+
+	return runs, nil
+}
+
+// Reconcile starts a reconciliation outside the schedule and returns its reports without waiting for it.
+// It fails with service.ErrReconcileInProgress if another reconciliation is running, and a
+// repair fails with service.ErrSyncInProgress while a sync is running.
+func (s *Scheduler) Reconcile(req service.ReconcileRequest) ([]*model.ReconcileReport, error) {
+	reports, run, err := s.reconcileService.Start(s.ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.manual.Add(1)
+	go func() {
+		defer s.manual.Done()
+		s.logger.Info("manual reconciliation triggered", "from", req.From, "to", req.To, "repair", req.Repair)
+		if err := run(); err != nil {
+			s.logger.Error("manual reconciliation failed", "error", err)
+		}
+	}()
+
+	return reports, nil
 }
 
 func (s *Scheduler) runBatchSync(ctx context.Context) {
@@ -69,3 +155,23 @@ func (s *Scheduler) runBatchSync(ctx context.Context) {
 		s.logger.Error("batch sync failed", "error", err)
 	}
 }
+
+func (s *Scheduler) runRetractionSweep(ctx context.Context) {
+	s.logger.Info("retraction sweep job triggered")
+	if err := s.retractionService.SweepAll(ctx); err != nil {
+		s.logger.Error("retraction sweep failed", "error", err)
+	}
+}
+
+func (s *Scheduler) runReconcile(ctx context.Context) {
+	s.logger.Info("reconciliation job triggered")
+	now := time.Now()
+	req := service.ReconcileRequest{
+		From:   now.Add(-s.reconcile.Window),
+		To:     now,
+		Repair: s.reconcile.AutoRepair,
+	}
+	if err := s.reconcileService.Reconcile(ctx, req); err != nil {
+		s.logger.Error("reconciliation failed", "error", err)
+	}
+}
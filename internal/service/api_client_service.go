@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+var (
+	// ErrInvalidAPIClient is returned for client or key settings that fail validation
+	ErrInvalidAPIClient = errors.New("invalid API client")
+	// ErrInvalidAPIKey is returned for unknown, expired or revoked keys and disabled clients
+	ErrInvalidAPIKey = errors.New("invalid API key")
+)
+
+const (
+	// apiKeyPrefix marks keys issued by this service
+	apiKeyPrefix = "hk_"
+	// apiKeyDisplayLength is how many leading key characters are stored in clear
+	apiKeyDisplayLength = 11
+	// apiKeyCacheTTL bounds how long a disabled client or revoked key keeps working
+	apiKeyCacheTTL = time.Minute
+)
+
+type cachedAPIClient struct {
+	client  *model.APIClient
+	expires time.Time
+}
+
+// APIClientService manages API clients and authenticates their keys
+type APIClientService struct {
+	goldRepo *repository.GoldRepository
+
+	mu    sync.Mutex
+	cache map[string]cachedAPIClient // by key hash
+}
+
+func NewAPIClientService(goldRepo *repository.GoldRepository) *APIClientService {
+	return &APIClientService{
+		goldRepo: goldRepo,
+		cache:    make(map[string]cachedAPIClient),
+	}
+}
+
+// Authenticate returns the client owning a key. Lookups are cached for apiKeyCacheTTL.
+func (s *APIClientService) Authenticate(ctx context.Context, key string) (*model.APIClient, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+	hash := hashAPIKey(key)
+	now := time.Now()
+
+	s.mu.Lock()
+	entry, ok := s.cache[hash]
+	s.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.client, nil
+	}
+
+	client, expiresAt, err := s.goldRepo.AuthenticateAPIKey(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if client == nil {
+		delete(s.cache, hash)
+		return nil, ErrInvalidAPIKey
+	}
+
+	entry = cachedAPIClient{client: client, expires: now.Add(apiKeyCacheTTL)}
+	if expiresAt != nil && expiresAt.Before(entry.expires) {
+		entry.expires = *expiresAt
+	}
+	// Drop stale entries so rotated keys do not accumulate
+	for h, e := range s.cache {
+		if now.After(e.expires) {
+			delete(s.cache, h)
+		}
+	}
+	s.cache[hash] = entry
+	return client, nil
+}
+
+// List returns paginated clients
+func (s *APIClientService) List(ctx context.Context, filter model.APIClientFilter) (*model.APIClientListResponse, error) {
+	// Set defaults
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	clients, total, err := s.goldRepo.ListAPIClients(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.APIClientListResponse{
+		Data: clients,
+		Pagination: model.Pagination{
+			Page:  filter.Page,
+			Limit: filter.Limit,
+			Total: total,
+		},
+	}, nil
+}
+
+// Get returns a client with its keys, or nil if it does not exist
+func (s *APIClientService) Get(ctx context.Context, id string) (*model.APIClient, error) {
+	return s.goldRepo.GetAPIClient(ctx, id)
+}
+
+// Create validates and stores a client together with its first key, which is
+// returned in clear only here
+func (s *APIClientService) Create(ctx context.Context, req model.APIClientRequest) (*model.APIClient, error) {
+	// Licenses are only granted explicitly, so a forgotten field cannot open every source
+	if req.Countries == nil {
+		return nil, fmt.Errorf("%w: countries is required", ErrInvalidAPIClient)
+	}
+	var c model.APIClient
+	if err := applyAPIClient(&c, req); err != nil {
+		return nil, err
+	}
+	if req.KeyExpiresAt != nil && !req.KeyExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: key_expires_at must be in the future", ErrInvalidAPIClient)
+	}
+
+	if err := s.goldRepo.CreateAPIClient(ctx, &c); err != nil {
+		return nil, err
+	}
+	key, err := s.CreateKey(ctx, c.ID, model.APIKeyRequest{ExpiresAt: req.KeyExpiresAt})
+	if err != nil {
+		return nil, err
+	}
+	c.Keys = []model.APIKey{*key}
+	return &c, nil
+}
+
+// Update replaces a client's settings. Returns nil if the client does not exist.
+func (s *APIClientService) Update(ctx context.Context, id string, req model.APIClientRequest) (*model.APIClient, error) {
+	c, err := s.goldRepo.GetAPIClient(ctx, id)
+	if err != nil || c == nil {
+		return nil, err
+	}
+	if err := applyAPIClient(c, req); err != nil {
+		return nil, err
+	}
+
+	found, err := s.goldRepo.UpdateAPIClient(ctx, c)
+	if err != nil || !found {
+		return nil, err
+	}
+	s.flushCache()
+	return c, nil
+}
+
+// CreateKey issues a new key for a client, optionally expiring its other keys after
+// a grace period. The key is returned in clear only here. Returns nil if the client
+// does not exist.
+func (s *APIClientService) CreateKey(ctx context.Context, clientID string, req model.APIKeyRequest) (*model.APIKey, error) {
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidAPIClient)
+	}
+	var existingExpireAt *time.Time
+	if req.ExistingKeyTTLHours != nil {
+		if *req.ExistingKeyTTLHours < 0 {
+			return nil, fmt.Errorf("%w: existing_key_ttl_hours must not be negative", ErrInvalidAPIClient)
+		}
+		t := now.Add(time.Duration(*req.ExistingKeyTTLHours) * time.Hour)
+		existingExpireAt = &t
+	}
+
+	secret, err := newAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	key := &model.APIKey{
+		Prefix:    secret[:apiKeyDisplayLength],
+		Key:       secret,
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	found, err := s.goldRepo.CreateAPIKey(ctx, clientID, key, hashAPIKey(secret), existingExpireAt)
+	if err != nil || !found {
+		return nil, err
+	}
+	if existingExpireAt != nil {
+		s.flushCache()
+	}
+	return key, nil
+}
+
+// RevokeKey revokes a key of a client. Returns false if no such live key exists.
+func (s *APIClientService) RevokeKey(ctx context.Context, clientID, keyID string) (bool, error) {
+	found, err := s.goldRepo.RevokeAPIKey(ctx, clientID, keyID)
+	if found {
+		s.flushCache()
+	}
+	return found, err
+}
+
+// flushCache makes changes take effect immediately on this replica; other
+// replicas pick them up within apiKeyCacheTTL
+func (s *APIClientService) flushCache() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.cache)
+}
+
+// applyAPIClient validates a request and copies it onto c. Countries and flags are
+// only changed if set, so an update cannot widen a client's license by omission.
+func applyAPIClient(c *model.APIClient, req model.APIClientRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAPIClient)
+	}
+	c.Name = name
+
+	if req.Countries != nil {
+		// An empty list would license every source
+		if len(req.Countries) == 0 {
+			return fmt.Errorf("%w: countries must not be empty", ErrInvalidAPIClient)
+		}
+		countries := make([]model.CountryCode, 0, len(req.Countries))
+		for _, country := range req.Countries {
+			cc := model.CountryCode(strings.ToUpper(strings.TrimSpace(country)))
+			if cc != model.CountryJP && cc != model.CountryCN {
+				return fmt.Errorf("%w: countries must be 'JP' or 'CN'", ErrInvalidAPIClient)
+			}
+			if !slices.Contains(countries, cc) {
+				countries = append(countries, cc)
+			}
+		}
+		c.Countries = countries
+	} else if c.Countries == nil {
+		c.Countries = []model.CountryCode{}
+	}
+
+	if req.Admin != nil {
+		c.Admin = *req.Admin
+	}
+	if req.Disabled != nil {
+		c.Disabled = *req.Disabled
+	}
+	return nil
+}
+
+// newAPIKey returns a random key with 192 bits of entropy
+func newAPIKey() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(b), nil
+}
+
+// hashAPIKey returns the hex SHA-256 of a key; keys are random, so no salt is needed
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+func TestAPIClientRequestValidation(t *testing.T) {
+	// Create validates before it touches the repository
+	s := NewAPIClientService(nil)
+
+	tests := []struct {
+		name string
+		req  model.APIClientRequest
+	}{
+		{name: "missing name", req: model.APIClientRequest{Countries: []string{"JP"}}},
+		{name: "missing countries", req: model.APIClientRequest{Name: "Hana MTS"}},
+		{name: "empty countries", req: model.APIClientRequest{Name: "Hana MTS", Countries: []string{}}},
+		{name: "unknown country", req: model.APIClientRequest{Name: "Hana MTS", Countries: []string{"JP", "US"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Create(context.Background(), tt.req); !errors.Is(err, ErrInvalidAPIClient) {
+				t.Errorf("Create error = %v, want ErrInvalidAPIClient", err)
+			}
+		})
+	}
+}
+
+func TestApplyAPIClient(t *testing.T) {
+	admin := true
+
+	// Countries are normalized and deduplicated
+	var c model.APIClient
+	if err := applyAPIClient(&c, model.APIClientRequest{Name: " Hana MTS ", Countries: []string{"jp", " CN", "JP"}, Admin: &admin}); err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "Hana MTS" || !c.Admin || !slices.Equal(c.Countries, []model.CountryCode{model.CountryJP, model.CountryCN}) {
+		t.Fatalf("client = %+v", c)
+	}
+
+	// An update without countries or flags keeps them
+	if err := applyAPIClient(&c, model.APIClientRequest{Name: "Renamed"}); err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "Renamed" || !c.Admin || len(c.Countries) != 2 {
+		t.Fatalf("client after update = %+v", c)
+	}
+
+	// An update cannot widen the license to every source with an empty list
+	if err := applyAPIClient(&c, model.APIClientRequest{Name: "Renamed", Countries: []string{}}); !errors.Is(err, ErrInvalidAPIClient) {
+		t.Fatalf("empty countries error = %v, want ErrInvalidAPIClient", err)
+	}
+}
@@ -2,172 +2,328 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
+	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/connector"
 	"github.com/onelineai/hana-news-api/internal/model"
 	"github.com/onelineai/hana-news-api/internal/repository"
 )
 
 const batchSize = 500
 
+var (
+	// ErrSyncInProgress is returned when a sync is requested while another one is running
+	ErrSyncInProgress = errors.New("sync already in progress")
+	// ErrUnknownSource is returned when a sync is requested for a source without a connector
+	ErrUnknownSource = errors.New("unknown news source")
+)
+
+// SyncRequest selects what a sync covers
+type SyncRequest struct {
+	// Source limits the sync to one source; nil syncs every registered source
+	Source *model.NewsSource
+	// BackfillFrom resets Source's cursor to this time before syncing
+	BackfillFrom *time.Time
+	Trigger      model.SyncTrigger
+}
+
 // BatchService handles ETL batch operations
 type BatchService struct {
-	silverRepo *repository.SilverRepository
+	connectors *connector.Registry
 	goldRepo   *repository.GoldRepository
+	cfg        config.BatchConfig
 	logger     *slog.Logger
+	breakers   map[model.NewsSource]*circuitBreaker
+	onSynced   []func()
+	// finishSyncRun stores the outcome of a run; replaced in tests
+	finishSyncRun func(ctx context.Context, run *model.SyncRun) error
+
+	// mu is held for the whole duration of a sync so scheduled and manual runs never overlap
+	mu sync.Mutex
 }
 
-func NewBatchService(silverRepo *repository.SilverRepository, goldRepo *repository.GoldRepository, logger *slog.Logger) *BatchService {
+func NewBatchService(connectors *connector.Registry, goldRepo *repository.GoldRepository, cfg config.BatchConfig, logger *slog.Logger) *BatchService {
+	breakers := make(map[model.NewsSource]*circuitBreaker)
+	for _, c := range connectors.All() {
+		breakers[c.Source()] = newCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown)
+	}
+
 	return &BatchService{
-		silverRepo: silverRepo,
+		connectors: connectors,
 		goldRepo:   goldRepo,
+		cfg:        cfg,
 		logger:     logger,
+		breakers:   breakers,
+
+		finishSyncRun: goldRepo.FinishSyncRun,
 	}
 }
 
-// SyncAll synchronizes all news sources from silver to gold
-func (s *BatchService) SyncAll(ctx context.Context) error {
-	s.logger.Info("starting batch sync")
-	start := time.Now()
+// OnSynced registers fn to run after every sync that wrote rows to gold.
+// Hooks must be registered before the first sync and should not block.
+func (s *BatchService) OnSynced(fn func()) {
+	s.onSynced = append(s.onSynced, fn)
+}
 
-	// Sync JP Minkabu news
-	jpCount, err := s.syncJPMinkabu(ctx)
-	if err != nil {
-		s.logger.Error("failed to sync JP Minkabu news", "error", err)
-		return err
+// SyncAll synchronizes all registered news sources from silver to gold.
+// It is a no-op if another sync is already running.
+func (s *BatchService) SyncAll(ctx context.Context) error {
+	_, run, err := s.StartSync(ctx, SyncRequest{Trigger: model.SyncTriggerScheduled})
+	if errors.Is(err, ErrSyncInProgress) {
+		s.logger.Info("skipping batch sync, another sync is in progress")
+		return nil
 	}
-
-	// Sync CN Wind news
-	cnCount, err := s.syncCNWind(ctx)
 	if err != nil {
-		s.logger.Error("failed to sync CN Wind news", "error", err)
 		return err
 	}
+	return run()
+}
 
-	s.logger.Info("batch sync completed",
-		"duration", time.Since(start),
-		"jp_minkabu_count", jpCount,
-		"cn_wind_count", cnCount,
-	)
+// StartSync acquires the sync lock, applies any backfill and records the runs for the
+// requested sources. The returned function performs the sync and releases the lock;
+// it must be called exactly once.
+func (s *BatchService) StartSync(ctx context.Context, req SyncRequest) ([]*model.SyncRun, func() error, error) {
+	if req.BackfillFrom != nil && req.Source == nil {
+		return nil, nil, errors.New("backfill requires a source")
+	}
 
-	return nil
-}
+	connectors := s.connectors.All()
+	if req.Source != nil {
+		c, ok := s.connectors.Get(*req.Source)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownSource, *req.Source)
+		}
+		connectors = []connector.Connector{c}
+	}
 
-func (s *BatchService) syncJPMinkabu(ctx context.Context) (int, error) {
-	source := model.SourceJPMinkabu
+	if !s.mu.TryLock() {
+		return nil, nil, ErrSyncInProgress
+	}
 
-	// Get last sync time
-	lastSync, err := s.goldRepo.GetLastSyncTime(ctx, source)
+	runs, err := s.prepareRuns(ctx, connectors, req)
 	if err != nil {
-		return 0, err
+		s.mu.Unlock()
+		return nil, nil, err
 	}
 
-	totalSynced := 0
-	var lastUpdatedAt time.Time
+	run := func() error {
+		defer s.mu.Unlock()
+		return s.syncRuns(ctx, connectors, runs)
+	}
+	return runs, run, nil
+}
 
-	for {
-		// Fetch batch from silver
-		news, err := s.silverRepo.GetJPMinkabuNewsSince(ctx, lastSync, batchSize)
-		if err != nil {
-			return totalSynced, err
-		}
-
-		if len(news) == 0 {
-			break
+func (s *BatchService) prepareRuns(ctx context.Context, connectors []connector.Connector, req SyncRequest) ([]*model.SyncRun, error) {
+	if req.BackfillFrom != nil {
+		cursor := model.SyncCursor{UpdatedAt: *req.BackfillFrom}
+		if err := s.goldRepo.ResetSyncCursor(ctx, *req.Source, cursor); err != nil {
+			return nil, fmt.Errorf("failed to reset sync cursor: %w", err)
 		}
+		s.logger.Info("sync cursor reset for backfill", "source", *req.Source, "from", *req.BackfillFrom)
+	}
 
-		s.logger.Debug("fetched JP Minkabu news batch", "count", len(news))
+	runs := make([]*model.SyncRun, 0, len(connectors))
+	for _, c := range connectors {
+		source := c.Source()
 
-		// Convert to unified format
-		unifiedNews := make([]*model.TranslatedNews, len(news))
-		for i, n := range news {
-			unifiedNews[i] = n.ToTranslatedNews()
+		// Get last sync cursor
+		cursor, err := s.goldRepo.GetSyncCursor(ctx, source)
+		if err != nil {
+			return nil, err
 		}
 
-		// Upsert to gold
-		affected, err := s.goldRepo.UpsertNews(ctx, unifiedNews)
+		run := &model.SyncRun{Source: source, Trigger: req.Trigger, CursorBefore: cursor, CursorAfter: cursor}
+		run.ID, err = s.goldRepo.CreateSyncRun(ctx, run)
 		if err != nil {
-			return totalSynced, err
+			return nil, fmt.Errorf("failed to record sync run: %w", err)
 		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
 
-		totalSynced += affected
-		lastUpdatedAt = news[len(news)-1].UpdatedAt
+// syncRuns syncs each source independently; one failing source does not stop the others
+func (s *BatchService) syncRuns(ctx context.Context, connectors []connector.Connector, runs []*model.SyncRun) error {
+	s.logger.Info("starting batch sync", "parallel", s.cfg.Parallel)
+	start := time.Now()
 
-		// Update last sync pointer for next iteration
-		lastSync = &lastUpdatedAt
+	// Number rows left without a sync sequence by a writer that stopped after committing
+	if err := s.goldRepo.AssignNewsSeq(ctx); err != nil {
+		s.logger.Warn("failed to assign pending sync sequences", "error", err)
+	}
 
-		// If we got less than batch size, we're done
-		if len(news) < batchSize {
-			break
+	errs := make([]error, len(connectors))
+	if s.cfg.Parallel {
+		var wg sync.WaitGroup
+		for i, c := range connectors {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = s.syncSource(ctx, c, runs[i])
+			}()
 		}
+		wg.Wait()
+	} else {
+		for i, c := range connectors {
+			errs[i] = s.syncSource(ctx, c, runs[i])
+		}
+	}
+
+	attrs := []any{"duration", time.Since(start)}
+	written := 0
+	for _, run := range runs {
+		attrs = append(attrs,
+			string(run.Source)+"_inserted", run.Inserted,
+			string(run.Source)+"_updated", run.Updated,
+			string(run.Source)+"_unchanged", run.Unchanged,
+			string(run.Source)+"_duplicate", run.Duplicate,
+		)
+		written += run.Written()
 	}
 
-	// Update sync metadata
-	if totalSynced > 0 {
-		if err := s.goldRepo.UpdateSyncMetadata(ctx, source, lastUpdatedAt, totalSynced); err != nil {
-			s.logger.Warn("failed to update sync metadata", "source", source, "error", err)
+	// Rows committed by a partly failed run still count
+	if written > 0 {
+		for _, fn := range s.onSynced {
+			fn()
 		}
 	}
 
-	return totalSynced, nil
+	if err := errors.Join(errs...); err != nil {
+		s.logger.Error("batch sync completed with errors", append(attrs, "error", err)...)
+		return err
+	}
+
+	s.logger.Info("batch sync completed", attrs...)
+
+	return nil
 }
 
-func (s *BatchService) syncCNWind(ctx context.Context) (int, error) {
-	source := model.SourceCNWind
+// syncSource syncs one source, retrying with exponential backoff. Scheduled runs are
+// skipped while the source's circuit breaker is open; manual runs always go through.
+func (s *BatchService) syncSource(ctx context.Context, c connector.Connector, run *model.SyncRun) error {
+	source := c.Source()
+	breaker := s.breakers[source]
+
+	if run.Trigger == model.SyncTriggerScheduled && !breaker.Allow(time.Now()) {
+		s.logger.Warn("skipping paused source", "source", source)
+		s.finishRun(ctx, run, model.SyncRunSkipped, errors.New("source paused by circuit breaker"))
+		return nil
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = s.copySource(ctx, c, run)
+		if err == nil || attempt >= s.cfg.MaxRetries || ctx.Err() != nil {
+			break
+		}
+
+		backoff := s.cfg.RetryBackoff << attempt
+		s.logger.Warn("sync attempt failed, retrying",
+			"source", source, "attempt", attempt+1, "backoff", backoff, "error", err)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+	}
 
-	// Get last sync time
-	lastSync, err := s.goldRepo.GetLastSyncTime(ctx, source)
 	if err != nil {
-		return 0, err
+		s.logger.Error("failed to sync news", "source", source, "error", err)
+		s.finishRun(ctx, run, model.SyncRunFailed, err)
+		breaker.Failure(time.Now(), err)
+		return fmt.Errorf("%s: %w", source, err)
+	}
+
+	s.finishRun(ctx, run, model.SyncRunSucceeded, nil)
+	breaker.Success(time.Now())
+	return nil
+}
+
+// Health returns the sync health of every registered source
+func (s *BatchService) Health() map[model.NewsSource]model.SourceHealth {
+	now := time.Now()
+	health := make(map[model.NewsSource]model.SourceHealth, len(s.breakers))
+	for source, breaker := range s.breakers {
+		health[source] = breaker.Health(now)
 	}
+	return health
+}
+
+// copySource pages through silver from run.CursorAfter, advancing the run's counters and cursor.
+// A large backlog is loaded with bulk COPY pages instead of per-row upserts.
+func (s *BatchService) copySource(ctx context.Context, c connector.Connector, run *model.SyncRun) error {
+	source := c.Source()
 
-	totalSynced := 0
-	var lastUpdatedAt time.Time
+	pageSize, upsert := batchSize, s.goldRepo.UpsertNewsWithCursor
+	if s.useBulk(ctx, c, run.CursorAfter) {
+		s.logger.Info("large backlog, using bulk load", "source", source, "page_size", s.cfg.BulkSize)
+		pageSize, upsert = s.cfg.BulkSize, s.goldRepo.BulkUpsertNewsWithCursor
+	}
 
 	for {
 		// Fetch batch from silver
-		news, err := s.silverRepo.GetCNWindNewsSince(ctx, lastSync, batchSize)
+		batch, err := c.FetchSince(ctx, run.CursorAfter, pageSize)
 		if err != nil {
-			return totalSynced, err
+			return err
 		}
 
-		if len(news) == 0 {
-			break
+		if len(batch.News) == 0 {
+			return nil
 		}
 
-		s.logger.Debug("fetched CN Wind news batch", "count", len(news))
+		s.logger.Debug("fetched news batch", "source", source, "count", len(batch.News))
+		run.RowsFetched += len(batch.News)
 
-		// Convert to unified format
-		unifiedNews := make([]*model.TranslatedNews, len(news))
-		for i, n := range news {
-			unifiedNews[i] = n.ToTranslatedNews()
-		}
-
-		// Upsert to gold
-		affected, err := s.goldRepo.UpsertNews(ctx, unifiedNews)
+		// Upsert to gold and checkpoint the cursor atomically
+		result, err := upsert(ctx, source, batch.News, batch.Next, run.Written())
 		if err != nil {
-			return totalSynced, err
+			return err
 		}
 
-		totalSynced += affected
-		lastUpdatedAt = news[len(news)-1].UpdatedAt
+		s.logger.Debug("upserted news batch", "source", source,
+			"inserted", result.Inserted, "updated", result.Updated, "unchanged", result.Unchanged, "duplicate", result.Duplicate)
+		run.Add(result)
 
-		// Update last sync pointer for next iteration
-		lastSync = &lastUpdatedAt
+		// Advance cursor for next iteration
+		next := batch.Next
+		run.CursorAfter = &next
 
 		// If we got less than batch size, we're done
-		if len(news) < batchSize {
-			break
+		if len(batch.News) < pageSize {
+			return nil
 		}
 	}
+}
 
-	// Update sync metadata
-	if totalSynced > 0 {
-		if err := s.goldRepo.UpdateSyncMetadata(ctx, source, lastUpdatedAt, totalSynced); err != nil {
-			s.logger.Warn("failed to update sync metadata", "source", source, "error", err)
-		}
+// useBulk reports whether the pending backlog of a source exceeds the bulk threshold
+func (s *BatchService) useBulk(ctx context.Context, c connector.Connector, cursor *model.SyncCursor) bool {
+	bc, ok := c.(connector.BacklogCounter)
+	if !ok || s.cfg.BulkThreshold <= 0 || s.cfg.BulkSize <= 0 {
+		return false
 	}
 
-	return totalSynced, nil
+	pending, err := bc.CountSince(ctx, cursor, s.cfg.BulkThreshold+1)
+	if err != nil {
+		s.logger.Warn("failed to count backlog, using per-row upserts", "source", c.Source(), "error", err)
+		return false
+	}
+	return pending > s.cfg.BulkThreshold
+}
+
+// finishRun stores the final state of a run, even if ctx was cancelled mid-sync
+func (s *BatchService) finishRun(ctx context.Context, run *model.SyncRun, status model.SyncRunStatus, runErr error) {
+	run.Status = status
+	if runErr != nil {
+		msg := runErr.Error()
+		run.Error = &msg
+	}
+
+	if err := s.finishSyncRun(context.WithoutCancel(ctx), run); err != nil {
+		s.logger.Warn("failed to update sync run", "source", run.Source, "run_id", run.ID, "error", err)
+	}
 }
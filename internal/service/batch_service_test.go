@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/connector"
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// fakeSource is a connector whose silver reads fail while failing is set
+type fakeSource struct {
+	source  model.NewsSource
+	failing bool
+	fetches int
+}
+
+func (f *fakeSource) Source() model.NewsSource {
+	return f.source
+}
+
+func (f *fakeSource) FetchSince(context.Context, *model.SyncCursor, int) (*connector.Batch, error) {
+	f.fetches++
+	if f.failing {
+		return nil, errors.New("silver unavailable")
+	}
+	return &connector.Batch{}, nil
+}
+
+// newTestBatchService returns a BatchService over one fake source that records
+// finished runs instead of writing them to gold
+func newTestBatchService(t *testing.T, cfg config.BatchConfig) (*BatchService, *fakeSource, *[]model.SyncRun) {
+	t.Helper()
+	src := &fakeSource{source: model.SourceJPMinkabu}
+	registry := connector.NewRegistry()
+	if err := registry.Register(src); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewBatchService(registry, nil, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	var finished []model.SyncRun
+	s.finishSyncRun = func(_ context.Context, run *model.SyncRun) error {
+		finished = append(finished, *run)
+		return nil
+	}
+	return s, src, &finished
+}
+
+// syncOnce runs one sync of the fake source
+func syncOnce(s *BatchService, src *fakeSource, trigger model.SyncTrigger) error {
+	return s.syncSource(context.Background(), src, &model.SyncRun{Source: src.source, Trigger: trigger})
+}
+
+func TestSyncSourceRetries(t *testing.T) {
+	s, src, finished := newTestBatchService(t, config.BatchConfig{MaxRetries: 2, RetryBackoff: time.Millisecond})
+
+	src.failing = true
+	if err := syncOnce(s, src, model.SyncTriggerScheduled); err == nil {
+		t.Fatal("sync of a failing source succeeded")
+	}
+	if src.fetches != 3 {
+		t.Errorf("fetches = %d, want 1 attempt and 2 retries", src.fetches)
+	}
+	if len(*finished) != 1 || (*finished)[0].Status != model.SyncRunFailed {
+		t.Errorf("finished runs = %+v, want one failed run", *finished)
+	}
+
+	// A retry that succeeds makes the run succeed
+	src.fetches = 0
+	src.failing = false
+	if err := syncOnce(s, src, model.SyncTriggerScheduled); err != nil {
+		t.Fatal(err)
+	}
+	if src.fetches != 1 {
+		t.Errorf("fetches = %d, want 1", src.fetches)
+	}
+}
+
+func TestRetryBackoffDoubles(t *testing.T) {
+	s, src, _ := newTestBatchService(t, config.BatchConfig{MaxRetries: 2, RetryBackoff: 20 * time.Millisecond})
+
+	src.failing = true
+	start := time.Now()
+	_ = syncOnce(s, src, model.SyncTriggerScheduled)
+	// 20ms after the first attempt, 40ms after the second
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("retries took %v, want at least 60ms of backoff", elapsed)
+	}
+}
+
+func TestSyncSourceCircuitBreaker(t *testing.T) {
+	s, src, finished := newTestBatchService(t, config.BatchConfig{BreakerThreshold: 2, BreakerCooldown: time.Hour})
+	breaker := s.breakers[src.source]
+
+	// The breaker opens after threshold failed runs
+	src.failing = true
+	for i := 0; i < 2; i++ {
+		_ = syncOnce(s, src, model.SyncTriggerScheduled)
+	}
+	health := s.Health()[src.source]
+	if health.Status != model.SourcePaused || health.ConsecutiveFailures != 2 {
+		t.Fatalf("health = %+v, want paused after 2 failures", health)
+	}
+
+	// Scheduled runs are skipped without touching silver
+	src.fetches = 0
+	if err := syncOnce(s, src, model.SyncTriggerScheduled); err != nil {
+		t.Fatalf("skipped run returned %v", err)
+	}
+	if src.fetches != 0 {
+		t.Errorf("fetches = %d, want the paused source left alone", src.fetches)
+	}
+	if last := (*finished)[len(*finished)-1]; last.Status != model.SyncRunSkipped {
+		t.Errorf("last run status = %s, want skipped", last.Status)
+	}
+
+	// Manual runs bypass the open breaker, and a success closes it
+	src.failing = false
+	if err := syncOnce(s, src, model.SyncTriggerManual); err != nil {
+		t.Fatal(err)
+	}
+	if src.fetches != 1 {
+		t.Errorf("fetches = %d, want the manual run to go through", src.fetches)
+	}
+	if health := s.Health()[src.source]; health.Status != model.SourceHealthy || health.LastSuccessAt == nil {
+		t.Errorf("health = %+v, want healthy after a success", health)
+	}
+	if !breaker.Allow(time.Now()) {
+		t.Error("breaker still open after a success")
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	b := newCircuitBreaker(2, time.Minute)
+	now := time.Date(2026, 1, 29, 10, 0, 0, 0, time.UTC)
+	failure := errors.New("silver unavailable")
+
+	b.Failure(now, failure)
+	if !b.Allow(now) || b.Health(now).Status != model.SourceDegraded {
+		t.Fatalf("breaker after one failure: allow = %v, health = %+v", b.Allow(now), b.Health(now))
+	}
+	b.Failure(now, failure)
+	if b.Allow(now.Add(59 * time.Second)) {
+		t.Fatal("breaker allowed a run during the cooldown")
+	}
+
+	// After the cooldown one trial run is allowed; its failure opens the breaker again
+	trial := now.Add(time.Minute)
+	if !b.Allow(trial) {
+		t.Fatal("breaker did not allow a trial run after the cooldown")
+	}
+	b.Failure(trial, failure)
+	if b.Allow(trial.Add(time.Second)) {
+		t.Error("breaker allowed a run right after a failed trial")
+	}
+
+	// A successful trial closes it
+	b.Success(trial.Add(time.Minute))
+	if health := b.Health(trial.Add(time.Minute)); health.Status != model.SourceHealthy || health.ConsecutiveFailures != 0 {
+		t.Errorf("health after success = %+v, want healthy", health)
+	}
+}
+
+func TestUnknownSource(t *testing.T) {
+	s, _, _ := newTestBatchService(t, config.BatchConfig{})
+	unknown := model.SourceCNWind
+
+	_, _, err := s.StartSync(context.Background(), SyncRequest{Source: &unknown, Trigger: model.SyncTriggerManual})
+	if !errors.Is(err, ErrUnknownSource) {
+		t.Errorf("StartSync error = %v, want ErrUnknownSource", err)
+	}
+
+	reconcile := NewReconcileService(s.connectors, nil, s, s.logger)
+	_, _, err = reconcile.Start(context.Background(), ReconcileRequest{Source: &unknown, From: time.Now().Add(-time.Hour), To: time.Now()})
+	if !errors.Is(err, ErrUnknownSource) {
+		t.Errorf("reconcile Start error = %v, want ErrUnknownSource", err)
+	}
+
+	// The lock is not left held by the rejected request
+	if !s.mu.TryLock() {
+		t.Fatal("sync lock held after an unknown source was rejected")
+	}
+	s.mu.Unlock()
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// circuitBreaker pauses a source after consecutive failed runs so a broken silver
+// table is not hammered every interval. After the cooldown one trial run is allowed;
+// success closes the breaker, failure opens it again.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+
+	failures      int
+	openUntil     time.Time
+	lastError     string
+	lastSuccessAt *time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow reports whether the source may be synced now
+func (b *circuitBreaker) Allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !now.Before(b.openUntil)
+}
+
+// Success records a successful run and closes the breaker
+func (b *circuitBreaker) Success(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+	b.lastError = ""
+	b.lastSuccessAt = &now
+}
+
+// Failure records a failed run and opens the breaker once the threshold is reached
+func (b *circuitBreaker) Failure(now time.Time, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.lastError = err.Error()
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
+
+// Health returns the source's current health
+func (b *circuitBreaker) Health(now time.Time) model.SourceHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	health := model.SourceHealth{
+		Status:              model.SourceHealthy,
+		ConsecutiveFailures: b.failures,
+		LastSuccessAt:       b.lastSuccessAt,
+	}
+	if b.failures > 0 {
+		health.Status = model.SourceDegraded
+		health.LastError = &b.lastError
+	}
+	if now.Before(b.openUntil) {
+		openUntil := b.openUntil
+		health.Status = model.SourcePaused
+		health.PausedUntil = &openUntil
+	}
+	return health
+}
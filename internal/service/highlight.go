@@ -0,0 +1,89 @@
+package service
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// snippetRadius is the number of runes kept on each side of the first content match
+const snippetRadius = 60
+
+// highlight builds search highlights for a news item
+func highlight(headline string, content *string, terms []string) *model.SearchHighlight {
+	if len(terms) == 0 {
+		return nil
+	}
+	h := &model.SearchHighlight{Headline: markTerms(headline, terms)}
+	if content != nil {
+		if snippet, ok := snippetAround(*content, terms); ok {
+			marked := markTerms(snippet, terms)
+			h.Snippet = &marked
+		}
+	}
+	return h
+}
+
+// snippetAround cuts a window of text around the earliest term match
+func snippetAround(text string, terms []string) (string, bool) {
+	lower := strings.ToLower(text)
+	first := -1
+	for _, term := range terms {
+		if i := strings.Index(lower, strings.ToLower(term)); i >= 0 && (first < 0 || i < first) {
+			first = i
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	runes := []rune(text)
+	// ToLower can change byte lengths, so locate the rune offset on the lowered text
+	pos := utf8.RuneCountInString(lower[:first])
+	start, end := max(pos-snippetRadius, 0), min(pos+snippetRadius, len(runes))
+	snippet := string(runes[start:end])
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet, true
+}
+
+// markTerms HTML-escapes text and wraps case-insensitive term matches in <em>
+func markTerms(text string, terms []string) string {
+	lower := strings.ToLower(text)
+	folded := len(lower) == len(text)
+	if !folded {
+		// Case folding changed byte offsets; fall back to exact matching
+		lower = text
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		matched := 0
+		for _, term := range terms {
+			t := term
+			if folded {
+				t = strings.ToLower(term)
+			}
+			if len(t) > matched && strings.HasPrefix(lower[i:], t) {
+				matched = len(t)
+			}
+		}
+		if matched > 0 {
+			b.WriteString("<em>")
+			b.WriteString(html.EscapeString(text[i : i+matched]))
+			b.WriteString("</em>")
+			i += matched
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		b.WriteString(html.EscapeString(text[i : i+size]))
+		i += size
+	}
+	return b.String()
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+// ErrInvalidInstrumentCSV is returned for CSV masters that cannot be parsed or validated
+var ErrInvalidInstrumentCSV = errors.New("invalid instrument CSV")
+
+// instrumentCSVColumns are the accepted CSV master columns; only ticker is required
+var instrumentCSVColumns = []string{"ticker", "exchange", "isin", "name_ko", "name_ja", "name_zh", "aliases"}
+
+// InstrumentService handles instrument search and master data import
+type InstrumentService struct {
+	goldRepo      *repository.GoldRepository
+	tickerService *TickerService
+}
+
+func NewInstrumentService(goldRepo *repository.GoldRepository, tickerService *TickerService) *InstrumentService {
+	return &InstrumentService{goldRepo: goldRepo, tickerService: tickerService}
+}
+
+// Search returns paginated instruments matching a code or company name
+func (s *InstrumentService) Search(ctx context.Context, filter model.InstrumentFilter) (*model.InstrumentListResponse, error) {
+	// Set defaults
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	items, total, err := s.goldRepo.SearchInstruments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.Instrument{}
+	}
+
+	return &model.InstrumentListResponse{
+		Data: items,
+		Pagination: model.Pagination{
+			Page:  filter.Page,
+			Limit: filter.Limit,
+			Total: total,
+		},
+	}, nil
+}
+
+// Get returns an instrument by any supported code, or nil if unknown
+func (s *InstrumentService) Get(ctx context.Context, code string) (*model.Instrument, error) {
+	tickers := s.tickerService.Normalize(ctx, []string{code})
+	if len(tickers) == 0 {
+		return nil, nil
+	}
+	return s.goldRepo.GetInstrument(ctx, tickers[0])
+}
+
+// Import upserts a CSV master with a header row. Columns are matched by name;
+// aliases are separated by '|'. The whole file is written in one transaction.
+func (s *InstrumentService) Import(ctx context.Context, r io.Reader) (*model.InstrumentImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: empty file", ErrInvalidInstrumentCSV)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidInstrumentCSV, err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for _, col := range instrumentCSVColumns {
+			if name == col {
+				index[name] = i
+			}
+		}
+	}
+	if _, ok := index["ticker"]; !ok {
+		return nil, fmt.Errorf("%w: header must include a ticker column", ErrInvalidInstrumentCSV)
+	}
+
+	field := func(record []string, col string) *string {
+		i, ok := index[col]
+		if !ok || i >= len(record) {
+			return nil
+		}
+		if v := strings.TrimSpace(record[i]); v != "" {
+			return &v
+		}
+		return nil
+	}
+
+	var instruments []model.Instrument
+	seen := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidInstrumentCSV, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		raw := field(record, "ticker")
+		if raw == nil {
+			return nil, fmt.Errorf("%w: line %d: ticker is required", ErrInvalidInstrumentCSV, line)
+		}
+		inst := model.Instrument{
+			Ticker: model.NormalizeTicker(*raw),
+			NameKO: field(record, "name_ko"),
+			NameJA: field(record, "name_ja"),
+			NameZH: field(record, "name_zh"),
+		}
+		if prev, dup := seen[inst.Ticker]; dup {
+			return nil, fmt.Errorf("%w: line %d: duplicate ticker %s (first on line %d)", ErrInvalidInstrumentCSV, line, inst.Ticker, prev)
+		}
+		seen[inst.Ticker] = line
+
+		// Blank exchanges and aliases are left empty so stored values are kept
+		if exchange := field(record, "exchange"); exchange != nil {
+			inst.Exchange = strings.ToUpper(*exchange)
+		}
+		if isin := field(record, "isin"); isin != nil {
+			v := strings.ToUpper(*isin)
+			inst.ISIN = &v
+		}
+		if aliases := field(record, "aliases"); aliases != nil {
+			for _, a := range strings.Split(*aliases, "|") {
+				if a = strings.ToUpper(strings.TrimSpace(a)); a != "" {
+					inst.Aliases = append(inst.Aliases, a)
+				}
+			}
+		}
+		instruments = append(instruments, inst)
+	}
+
+	if err := s.goldRepo.UpsertInstruments(ctx, instruments); err != nil {
+		return nil, err
+	}
+	return &model.InstrumentImportResult{Rows: len(instruments)}, nil
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/auth"
+	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+var (
+	// ErrBrokerClosed is returned when subscribing after the broker stopped
+	ErrBrokerClosed = errors.New("news broker closed")
+	// ErrTooManySubscriptions is returned when a ticker subscription exceeds the configured limit
+	ErrTooManySubscriptions = errors.New("too many subscriptions")
+)
+
+const (
+	// brokerPageSize is the number of rows read per query when catching up
+	brokerPageSize = 500
+	// brokerPollInterval catches up even if a notification was missed
+	brokerPollInterval = 30 * time.Second
+	// brokerRetryDelay is the wait before re-establishing LISTEN
+	brokerRetryDelay = 5 * time.Second
+	// subscriptionBuffer is how many events a slow subscriber may lag behind
+	// before it is dropped and has to resume with Last-Event-ID
+	subscriptionBuffer = 256
+)
+
+// NewsSubscription receives news events matching its filter
+type NewsSubscription struct {
+	filter model.NewsFilter
+	// explicit subscriptions only receive news for their tickers; an empty
+	// ticker list then matches nothing instead of everything
+	explicit bool
+	events   chan model.NewsEvent
+	lagged   bool
+}
+
+// Events is closed when the subscriber falls too far behind or the broker stops
+func (s *NewsSubscription) Events() <-chan model.NewsEvent {
+	return s.events
+}
+
+// Lagged reports whether the subscription was dropped for falling behind.
+// Only meaningful once Events is closed.
+func (s *NewsSubscription) Lagged() bool {
+	return s.lagged
+}
+
+// NewsBroker fans out newly synced news to stream subscribers. Each replica
+// LISTENs for gold write notifications and reads new rows by sync sequence,
+// so subscribers see writes committed by any replica.
+type NewsBroker struct {
+	goldRepo      *repository.GoldRepository
+	tickerService *TickerService
+	cfg           config.StreamConfig
+	logger        *slog.Logger
+
+	mu     sync.Mutex
+	subs   map[*NewsSubscription]struct{}
+	closed bool
+
+	seq  int64 // last sync sequence fanned out
+	wake chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewNewsBroker(goldRepo *repository.GoldRepository, tickerService *TickerService, cfg config.StreamConfig, logger *slog.Logger) *NewsBroker {
+	return &NewsBroker{
+		goldRepo:      goldRepo,
+		tickerService: tickerService,
+		cfg:           cfg,
+		logger:        logger,
+		subs:          make(map[*NewsSubscription]struct{}),
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// Start begins listening from the latest committed news. It runs until ctx is done.
+func (b *NewsBroker) Start(ctx context.Context) error {
+	seq, err := b.goldRepo.GetLatestNewsSeq(ctx)
+	if err != nil {
+		return err
+	}
+	b.seq = seq
+
+	b.wg.Add(2)
+	go b.listen(ctx)
+	go b.dispatch(ctx)
+	return nil
+}
+
+// Stop waits for the broker to finish after its context is cancelled
+func (b *NewsBroker) Stop() {
+	b.wg.Wait()
+}
+
+// Subscribe registers a subscriber for news matching the filter's source and tickers,
+// limited to the sources the caller is licensed for
+func (b *NewsBroker) Subscribe(ctx context.Context, filter model.NewsFilter) (*NewsSubscription, error) {
+	if len(filter.Tickers) > 0 {
+		filter.Tickers = b.tickerService.Normalize(ctx, filter.Tickers)
+	}
+	sub := &NewsSubscription{
+		filter: model.NewsFilter{Source: filter.Source, Sources: auth.AllowedSources(ctx), Tickers: filter.Tickers},
+		events: make(chan model.NewsEvent, subscriptionBuffer),
+	}
+	return sub, b.register(sub)
+}
+
+// SubscribeTickers registers a subscriber whose tickers are managed with
+// UpdateTickers. It receives nothing until tickers are added.
+func (b *NewsBroker) SubscribeTickers(ctx context.Context) (*NewsSubscription, error) {
+	sub := &NewsSubscription{
+		filter:   model.NewsFilter{Sources: auth.AllowedSources(ctx)},
+		explicit: true,
+		events:   make(chan model.NewsEvent, subscriptionBuffer),
+	}
+	return sub, b.register(sub)
+}
+
+func (b *NewsBroker) register(sub *NewsSubscription) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrBrokerClosed
+	}
+	b.subs[sub] = struct{}{}
+	return nil
+}
+
+// UpdateTickers adds and removes tickers of a subscription and returns the
+// resulting ticker list. It fails without changes if the result would exceed
+// the configured limit.
+func (b *NewsBroker) UpdateTickers(ctx context.Context, sub *NewsSubscription, add, remove []string) ([]string, error) {
+	add = b.tickerService.Normalize(ctx, add)
+	remove = b.tickerService.Normalize(ctx, remove)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	tickers := slices.Clone(sub.filter.Tickers)
+	for _, t := range add {
+		if !slices.Contains(tickers, t) {
+			tickers = append(tickers, t)
+		}
+	}
+	tickers = slices.DeleteFunc(tickers, func(t string) bool {
+		return slices.Contains(remove, t)
+	})
+	if b.cfg.MaxSubscriptions > 0 && len(tickers) > b.cfg.MaxSubscriptions {
+		return slices.Clone(sub.filter.Tickers), ErrTooManySubscriptions
+	}
+
+	slices.Sort(tickers)
+	sub.filter.Tickers = tickers
+	return slices.Clone(tickers), nil
+}
+
+// Unsubscribe removes a subscriber; it is safe to call after the broker dropped it
+func (b *NewsBroker) Unsubscribe(sub *NewsSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
+
+// Replay returns up to limit events for the subscription written after the given sequence
+func (b *NewsBroker) Replay(ctx context.Context, sub *NewsSubscription, afterSeq int64, limit int) ([]model.NewsEvent, error) {
+	var events []model.NewsEvent
+	for len(events) < limit {
+		page, err := b.goldRepo.ListNewsSince(ctx, afterSeq, sub.filter, min(brokerPageSize, limit-len(events)))
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page...)
+		if len(page) < brokerPageSize {
+			break
+		}
+		afterSeq = page[len(page)-1].Seq
+	}
+	return events, nil
+}
+
+// listen keeps a LISTEN connection open, re-establishing it after failures
+func (b *NewsBroker) listen(ctx context.Context) {
+	defer b.wg.Done()
+	for {
+		err := b.goldRepo.ListenNewsSynced(ctx, b.notify)
+		if ctx.Err() != nil {
+			return
+		}
+		b.logger.Error("news listener failed, retrying", "error", err)
+		// Writes during the outage are picked up by the next fetch
+		b.notify()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(brokerRetryDelay):
+		}
+	}
+}
+
+// notify wakes the dispatcher; bursts of notifications coalesce into one fetch
+func (b *NewsBroker) notify() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch fetches new rows on notification or poll and fans them out
+func (b *NewsBroker) dispatch(ctx context.Context) {
+	defer b.wg.Done()
+	defer b.closeAll()
+
+	ticker := time.NewTicker(brokerPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.wake:
+		case <-ticker.C:
+		}
+
+		if err := b.fetch(ctx); err != nil && ctx.Err() == nil {
+			b.logger.Error("failed to fetch synced news", "error", err)
+		}
+	}
+}
+
+// fetch reads every row written since the last fan-out
+func (b *NewsBroker) fetch(ctx context.Context) error {
+	for {
+		events, err := b.goldRepo.ListNewsSince(ctx, b.seq, model.NewsFilter{}, brokerPageSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			b.publish(e)
+		}
+		if len(events) > 0 {
+			b.seq = events[len(events)-1].Seq
+		}
+		if len(events) < brokerPageSize {
+			return nil
+		}
+	}
+}
+
+// publish delivers an event to matching subscribers, dropping those that are full
+func (b *NewsBroker) publish(e model.NewsEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		matched, ok := matchSubscription(sub, e)
+		if !ok {
+			continue
+		}
+		ev := e
+		ev.Item.MatchedTickers = matched
+		select {
+		case sub.events <- ev:
+		default:
+			sub.lagged = true
+			delete(b.subs, sub)
+			close(sub.events)
+		}
+	}
+}
+
+// closeAll ends every subscription when the broker stops
+func (b *NewsBroker) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
+
+// matchSubscription reports whether an event passes a subscription filter and
+// which of the requested tickers it mentions
+func matchSubscription(sub *NewsSubscription, e model.NewsEvent) ([]string, bool) {
+	filter := sub.filter
+	if filter.Source != nil && *filter.Source != e.Source {
+		return nil, false
+	}
+	if filter.Sources != nil && !slices.Contains(filter.Sources, e.Source) {
+		return nil, false
+	}
+	if len(filter.Tickers) == 0 {
+		return nil, !sub.explicit
+	}
+	var matched []string
+	for _, t := range e.Tickers {
+		if slices.Contains(filter.Tickers, t) {
+			matched = append(matched, t)
+		}
+	}
+	return matched, len(matched) > 0
+}
@@ -2,21 +2,26 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/onelineai/hana-news-api/internal/model"
 	"github.com/onelineai/hana-news-api/internal/repository"
 )
 
+// ErrNewsRetracted is returned for news withdrawn or removed from the source
+var ErrNewsRetracted = errors.New("news retracted")
+
 // NewsService handles news query operations
 type NewsService struct {
-	goldRepo *repository.GoldRepository
+	goldRepo      *repository.GoldRepository
+	tickerService *TickerService
 }
 
-func NewNewsService(goldRepo *repository.GoldRepository) *NewsService {
-	return &NewsService{goldRepo: goldRepo}
+func NewNewsService(goldRepo *repository.GoldRepository, tickerService *TickerService) *NewsService {
+	return &NewsService{goldRepo: goldRepo, tickerService: tickerService}
 }
 
-// ListNews returns paginated news list
+// ListNews returns a page of news using either page/limit or keyset cursors
 func (s *NewsService) ListNews(ctx context.Context, filter model.NewsFilter) (*model.NewsListResponse, error) {
 	// Set defaults
 	if filter.Page <= 0 {
@@ -28,23 +33,90 @@ func (s *NewsService) ListNews(ctx context.Context, filter model.NewsFilter) (*m
 	if filter.Limit > 100 {
 		filter.Limit = 100
 	}
+	if filter.Sort == "" {
+		filter.Sort = model.SortLatest
+		// Cursors follow (published_at, id), so relevance only applies to page/limit
+		if (filter.Query != nil || filter.OriginalQuery != nil) && filter.Cursor == nil {
+			filter.Sort = model.SortRelevance
+		}
+	}
+	if filter.Count == "" {
+		filter.Count = model.CountExact
+		if filter.Cursor != nil {
+			filter.Count = model.CountNone
+		}
+	}
+
+	if len(filter.Tickers) > 0 {
+		filter.Tickers = s.tickerService.Normalize(ctx, filter.Tickers)
+	}
 
-	items, total, err := s.goldRepo.ListNews(ctx, filter)
+	items, hasMore, err := s.goldRepo.ListNews(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
+	if filter.Query != nil {
+		terms := model.SearchTerms(*filter.Query)
+		for i := range items {
+			items[i].Highlight = highlight(items[i].Headline, items[i].Content, terms)
+		}
+	}
+
+	pagination := model.NewsPagination{Pagination: model.Pagination{Limit: filter.Limit}}
+	if filter.Cursor == nil {
+		pagination.Page = filter.Page
+	}
+
+	if filter.Count != model.CountNone {
+		estimate := filter.Count == model.CountEstimate
+		total, err := s.goldRepo.CountNews(ctx, filter, estimate)
+		if err != nil {
+			return nil, err
+		}
+		pagination.Total = total
+		pagination.TotalEstimated = estimate
+	}
+
+	if filter.Sort == model.SortLatest && len(items) > 0 {
+		backward := filter.Cursor != nil && filter.Cursor.Backward
+		// A backward page always has the page it came from after it
+		moreAfter := hasMore || backward
+		moreBefore := filter.Page > 1 || filter.Cursor != nil
+		if backward {
+			moreBefore = hasMore
+		}
+
+		if moreAfter {
+			last := items[len(items)-1]
+			next := model.NewsCursor{PublishedAt: last.PublishedAt, ID: last.ID}.Encode()
+			pagination.NextCursor = &next
+		}
+		if moreBefore {
+			first := items[0]
+			prev := model.NewsCursor{PublishedAt: first.PublishedAt, ID: first.ID, Backward: true}.Encode()
+			pagination.PrevCursor = &prev
+		}
+	}
+
 	return &model.NewsListResponse{
-		Data: items,
-		Pagination: model.Pagination{
-			Page:  filter.Page,
-			Limit: filter.Limit,
-			Total: total,
-		},
+		Data:       items,
+		Pagination: pagination,
 	}, nil
 }
 
 // GetNewsDetail returns detailed news by ID
 func (s *NewsService) GetNewsDetail(ctx context.Context, id string) (*model.NewsDetail, error) {
-	return s.goldRepo.GetNewsDetail(ctx, id)
+	detail, err := s.goldRepo.GetNewsDetail(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if detail == nil {
+		return nil, nil
+	}
+	if detail.DeletedAt != nil {
+		return nil, ErrNewsRetracted
+	}
+	detail.Tickers = s.tickerService.Describe(ctx, detail.TickerCodes)
+	return detail, nil
 }
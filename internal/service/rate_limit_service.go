@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+const (
+	// quotaFlushInterval is how often counted requests are written to gold
+	quotaFlushInterval = 10 * time.Second
+	// rateLimitIdle is how long an untouched bucket is kept; any bucket refills well within it
+	rateLimitIdle = time.Hour
+	// storeErrorLogInterval limits store error logging to one line per interval
+	storeErrorLogInterval = 10 * time.Second
+)
+
+// billingLocation is the time zone of quota days and usage report months. Korea has no DST.
+var billingLocation = time.FixedZone("Asia/Seoul", 9*60*60)
+
+// RateLimit is the outcome of taking a token from a bucket
+type RateLimit struct {
+	Allowed bool
+	// Limit is the bucket size
+	Limit int
+	// Remaining is the number of whole tokens left
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next token if the request was not allowed
+	RetryAfter time.Duration
+}
+
+// bucketSpec names a token bucket refilled at rate tokens per second up to burst
+type bucketSpec struct {
+	key   string
+	rate  float64
+	burst int
+}
+
+// bucketTake is the outcome of taking a token from one bucket
+type bucketTake struct {
+	tokens  float64
+	allowed bool
+}
+
+// rateLimitStore keeps token buckets. take takes a token from each bucket that has a
+// whole one and returns the outcomes in the order of buckets.
+type rateLimitStore interface {
+	take(ctx context.Context, buckets []bucketSpec) ([]bucketTake, error)
+	prune(ctx context.Context, idle time.Duration) error
+}
+
+type quotaKey struct {
+	clientID string
+	day      string
+}
+
+// RateLimitService enforces token bucket limits per API client and per IP address and
+// counts client requests towards their daily quota counters. If the shared store
+// fails, requests are limited per replica by an in-memory fallback instead of being
+// rejected, so a database problem does not take the whole API down.
+type RateLimitService struct {
+	store    rateLimitStore
+	fallback *memoryRateLimitStore
+	goldRepo *repository.GoldRepository
+	cfg      config.RateLimitConfig
+	logger   *slog.Logger
+
+	storeErrors  atomic.Int64
+	errorsLogged atomic.Int64 // storeErrors when the last error was logged
+	lastErrorLog atomic.Int64 // unix nanoseconds
+
+	mu      sync.Mutex
+	pending map[quotaKey]*model.ClientQuotaDelta
+	wg      sync.WaitGroup
+}
+
+func NewRateLimitService(goldRepo *repository.GoldRepository, cfg config.RateLimitConfig, logger *slog.Logger) *RateLimitService {
+	var store rateLimitStore = newMemoryRateLimitStore()
+	if cfg.Store == config.RateLimitStorePostgres {
+		store = postgresRateLimitStore{goldRepo: goldRepo}
+	}
+	return &RateLimitService{
+		store:    store,
+		fallback: newMemoryRateLimitStore(),
+		goldRepo: goldRepo,
+		cfg:      cfg,
+		logger:   logger,
+		pending:  make(map[quotaKey]*model.ClientQuotaDelta),
+	}
+}
+
+// AllowIP takes a token from the bucket of an IP address. Returns nil if the limit is disabled.
+func (s *RateLimitService) AllowIP(ctx context.Context, ip string) *RateLimit {
+	return s.Allow(ctx, ip, "")
+}
+
+// Allow takes a token from the bucket of an IP address and, if clientID is set, from
+// the bucket of the client, shared by all of its keys, in one store round trip. The
+// client's limit is returned unless only the IP bucket was empty. Client requests are
+// counted towards the quota. Returns nil if both limits are disabled.
+func (s *RateLimitService) Allow(ctx context.Context, ip, clientID string) *RateLimit {
+	var specs []bucketSpec
+	ipIdx, clientIdx := -1, -1
+	if s.cfg.IPRate > 0 {
+		ipIdx = len(specs)
+		specs = append(specs, newBucketSpec("ip:"+ip, s.cfg.IPRate, s.cfg.IPBurst))
+	}
+	if clientID != "" && s.cfg.ClientRate > 0 {
+		clientIdx = len(specs)
+		specs = append(specs, newBucketSpec("client:"+clientID, s.cfg.ClientRate, s.cfg.ClientBurst))
+	}
+
+	var limit *RateLimit
+	if len(specs) > 0 {
+		taken := s.take(ctx, specs)
+		if clientIdx >= 0 {
+			limit = newRateLimit(specs[clientIdx], taken[clientIdx])
+		}
+		if ipIdx >= 0 && (limit == nil || !taken[ipIdx].allowed) {
+			limit = newRateLimit(specs[ipIdx], taken[ipIdx])
+		}
+	}
+
+	if clientID != "" {
+		s.count(clientID, limit == nil || limit.Allowed)
+	}
+	return limit
+}
+
+// Quota returns the counters of a client for today and the month of the given time
+// (the current month if nil). Returns nil if the client does not exist.
+func (s *RateLimitService) Quota(ctx context.Context, clientID string, month *time.Time) (*model.APIClientQuota, error) {
+	client, err := s.goldRepo.GetAPIClient(ctx, clientID)
+	if err != nil || client == nil {
+		return nil, err
+	}
+
+	now := time.Now().In(billingLocation)
+	today := now.Format(time.DateOnly)
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, billingLocation)
+	if month != nil {
+		start = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, billingLocation)
+	}
+
+	daily, err := s.goldRepo.ListClientQuota(ctx, clientID, start.Format(time.DateOnly), start.AddDate(0, 1, 0).Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &model.APIClientQuota{
+		ClientID: clientID,
+		Timezone: billingLocation.String(),
+		Today:    model.QuotaUsage{Period: today},
+		Month:    model.QuotaUsage{Period: start.Format("2006-01")},
+		Daily:    daily,
+	}
+	for _, d := range daily {
+		quota.Month.Requests += d.Requests
+		quota.Month.RateLimited += d.RateLimited
+	}
+
+	todays, err := s.goldRepo.ListClientQuota(ctx, clientID, today, now.AddDate(0, 0, 1).Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	if len(todays) > 0 {
+		quota.Today = todays[0]
+	}
+	return quota, nil
+}
+
+// Start flushes quota counters and prunes idle buckets in the background until ctx is cancelled
+func (s *RateLimitService) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop waits for the background work to end after the context is cancelled and writes
+// the remaining counters. Call it once the HTTP server has drained.
+func (s *RateLimitService) Stop() {
+	s.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.flush(ctx)
+}
+
+func (s *RateLimitService) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(quotaFlushInterval)
+	defer ticker.Stop()
+	lastPrune := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.flush(ctx)
+		if time.Since(lastPrune) >= rateLimitIdle {
+			if err := s.store.prune(ctx, rateLimitIdle); err != nil && ctx.Err() == nil {
+				s.logger.Error("failed to prune rate limit buckets", "error", err)
+			}
+			_ = s.fallback.prune(ctx, rateLimitIdle)
+			lastPrune = time.Now()
+		}
+	}
+}
+
+func newBucketSpec(key string, rate, burst int) bucketSpec {
+	if burst < 1 {
+		burst = rate
+	}
+	return bucketSpec{key: key, rate: float64(rate), burst: burst}
+}
+
+// take takes a token from each bucket, falling back to the in-memory store if the
+// configured one fails
+func (s *RateLimitService) take(ctx context.Context, specs []bucketSpec) []bucketTake {
+	taken, err := s.store.take(ctx, specs)
+	if err == nil {
+		return taken
+	}
+	s.storeFailed(err)
+	taken, _ = s.fallback.take(ctx, specs)
+	return taken
+}
+
+// storeFailed counts a store error and logs at most once per storeErrorLogInterval
+func (s *RateLimitService) storeFailed(err error) {
+	total := s.storeErrors.Add(1)
+	now := time.Now().UnixNano()
+	last := s.lastErrorLog.Load()
+	if now-last < int64(storeErrorLogInterval) || !s.lastErrorLog.CompareAndSwap(last, now) {
+		return
+	}
+	since := total - s.errorsLogged.Swap(total)
+	s.logger.Error("rate limit store failed, limiting per replica", "error", err, "failures", since, "total_failures", total)
+}
+
+// newRateLimit describes the outcome of taking a token from a bucket
+func newRateLimit(spec bucketSpec, t bucketTake) *RateLimit {
+	limit := &RateLimit{
+		Allowed:   t.allowed,
+		Limit:     spec.burst,
+		Remaining: max(int(t.tokens), 0),
+		Reset:     time.Duration((float64(spec.burst) - t.tokens) / spec.rate * float64(time.Second)),
+	}
+	if !t.allowed {
+		limit.RetryAfter = time.Duration(math.Max(1-t.tokens, 0) / spec.rate * float64(time.Second))
+	}
+	return limit
+}
+
+// count adds a request of a client to today's pending counters
+func (s *RateLimitService) count(clientID string, allowed bool) {
+	key := quotaKey{clientID: clientID, day: time.Now().In(billingLocation).Format(time.DateOnly)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.pending[key]
+	if !ok {
+		d = &model.ClientQuotaDelta{ClientID: key.clientID, Day: key.day}
+		s.pending[key] = d
+	}
+	if allowed {
+		d.Requests++
+	} else {
+		d.RateLimited++
+	}
+}
+
+// flush writes the pending counters to gold. Counters that fail to be written are
+// kept for the next flush.
+func (s *RateLimitService) flush(ctx context.Context) {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[quotaKey]*model.ClientQuotaDelta)
+	s.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	deltas := make([]model.ClientQuotaDelta, 0, len(pending))
+	for _, d := range pending {
+		deltas = append(deltas, *d)
+	}
+	if err := s.goldRepo.AddClientQuota(ctx, deltas); err != nil {
+		s.logger.Error("failed to write quota counters", "error", err, "clients", len(deltas))
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for key, d := range pending {
+			if cur, ok := s.pending[key]; ok {
+				cur.Requests += d.Requests
+				cur.RateLimited += d.RateLimited
+			} else {
+				s.pending[key] = d
+			}
+		}
+	}
+}
+
+// memoryRateLimitStore keeps buckets in process; each replica limits independently
+type memoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func newMemoryRateLimitStore() *memoryRateLimitStore {
+	return &memoryRateLimitStore{buckets: make(map[string]*tokenBucket)}
+}
+
+func (m *memoryRateLimitStore) take(_ context.Context, buckets []bucketSpec) ([]bucketTake, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	taken := make([]bucketTake, len(buckets))
+	for i, spec := range buckets {
+		b, ok := m.buckets[spec.key]
+		if !ok {
+			b = &tokenBucket{tokens: float64(spec.burst)}
+			m.buckets[spec.key] = b
+		} else {
+			b.tokens = min(float64(spec.burst), b.tokens+now.Sub(b.updated).Seconds()*spec.rate)
+		}
+		b.updated = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			taken[i] = bucketTake{tokens: b.tokens, allowed: true}
+		} else {
+			taken[i] = bucketTake{tokens: b.tokens}
+		}
+	}
+	return taken, nil
+}
+
+func (m *memoryRateLimitStore) prune(_ context.Context, idle time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, b := range m.buckets {
+		if time.Since(b.updated) > idle {
+			delete(m.buckets, key)
+		}
+	}
+	return nil
+}
+
+// postgresRateLimitStore keeps buckets in gold so all replicas share them
+type postgresRateLimitStore struct {
+	goldRepo *repository.GoldRepository
+}
+
+func (p postgresRateLimitStore) take(ctx context.Context, buckets []bucketSpec) ([]bucketTake, error) {
+	keys := make([]string, len(buckets))
+	rates := make([]float64, len(buckets))
+	bursts := make([]int, len(buckets))
+	for i, b := range buckets {
+		keys[i], rates[i], bursts[i] = b.key, b.rate, b.burst
+	}
+	tokens, allowed, err := p.goldRepo.TakeRateLimitTokens(ctx, keys, rates, bursts)
+	if err != nil {
+		return nil, err
+	}
+	taken := make([]bucketTake, len(buckets))
+	for i := range taken {
+		taken[i] = bucketTake{tokens: tokens[i], allowed: allowed[i]}
+	}
+	return taken, nil
+}
+
+func (p postgresRateLimitStore) prune(ctx context.Context, idle time.Duration) error {
+	return p.goldRepo.PruneRateLimitBuckets(ctx, idle)
+}
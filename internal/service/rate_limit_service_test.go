@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/config"
+)
+
+// failingRateLimitStore is a shared store that is down
+type failingRateLimitStore struct {
+	calls int
+}
+
+func (f *failingRateLimitStore) take(context.Context, []bucketSpec) ([]bucketTake, error) {
+	f.calls++
+	return nil, errors.New("connection refused")
+}
+
+func (f *failingRateLimitStore) prune(context.Context, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func newTestRateLimitService(cfg config.RateLimitConfig) *RateLimitService {
+	return NewRateLimitService(nil, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestRateLimitAllow(t *testing.T) {
+	s := newTestRateLimitService(config.RateLimitConfig{IPRate: 1, IPBurst: 4, ClientRate: 1, ClientBurst: 2})
+	ctx := context.Background()
+
+	// Authenticated requests report the client bucket
+	for i, wantAllowed := range []bool{true, true, false} {
+		limit := s.Allow(ctx, "203.0.113.7", "client-1")
+		if limit == nil || limit.Allowed != wantAllowed || limit.Limit != 2 {
+			t.Fatalf("request %d: limit = %+v, want allowed %v with client limit 2", i, limit, wantAllowed)
+		}
+	}
+
+	// The IP bucket was charged by every request above, including the rejected one, so
+	// one token is left for another client on the same address, then the IP limit applies
+	if limit := s.Allow(ctx, "203.0.113.7", "client-2"); limit == nil || !limit.Allowed {
+		t.Fatalf("client-2 first request: limit = %+v, want allowed", limit)
+	}
+	limit := s.Allow(ctx, "203.0.113.7", "client-2")
+	if limit == nil || limit.Allowed || limit.Limit != 4 {
+		t.Fatalf("client-2 second request: limit = %+v, want rejected by the IP limit 4", limit)
+	}
+	if limit.RetryAfter <= 0 {
+		t.Errorf("RetryAfter = %v, want > 0", limit.RetryAfter)
+	}
+
+	// Rejected requests are counted separately from allowed ones
+	d := s.pending[quotaKey{clientID: "client-1", day: time.Now().In(billingLocation).Format(time.DateOnly)}]
+	if d == nil || d.Requests != 2 || d.RateLimited != 1 {
+		t.Errorf("client-1 quota = %+v, want 2 requests and 1 rate limited", d)
+	}
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	s := newTestRateLimitService(config.RateLimitConfig{})
+	if limit := s.Allow(context.Background(), "203.0.113.7", "client-1"); limit != nil {
+		t.Errorf("limit = %+v, want nil", limit)
+	}
+}
+
+func TestRateLimitStoreFailureFallsBack(t *testing.T) {
+	s := newTestRateLimitService(config.RateLimitConfig{ClientRate: 1, ClientBurst: 2})
+	store := &failingRateLimitStore{}
+	s.store = store
+	ctx := context.Background()
+
+	// Requests keep being served and limited per replica while the store is down
+	for i, wantAllowed := range []bool{true, true, false} {
+		limit := s.Allow(ctx, "203.0.113.7", "client-1")
+		if limit == nil || limit.Allowed != wantAllowed {
+			t.Fatalf("request %d: limit = %+v, want allowed %v", i, limit, wantAllowed)
+		}
+	}
+	if store.calls != 3 {
+		t.Errorf("store calls = %d, want the store retried on every request", store.calls)
+	}
+	if got := s.storeErrors.Load(); got != 3 {
+		t.Errorf("store errors = %d, want 3", got)
+	}
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/connector"
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+// ErrReconcileInProgress is returned when a reconciliation is requested while another one is running
+var ErrReconcileInProgress = errors.New("reconciliation already in progress")
+
+// ReconcileRequest selects what a reconciliation covers
+type ReconcileRequest struct {
+	// Source limits the check to one source; nil checks every registered source
+	Source *model.NewsSource
+	// From and To bound silver updated_at / gold source_updated_at, [From, To)
+	From time.Time
+	To   time.Time
+	// Repair re-upserts missing and stale rows from silver and soft-deletes extra rows.
+	// It holds the batch sync lock, so it fails with ErrSyncInProgress while a sync runs.
+	Repair bool
+}
+
+// ReconcileService compares silver and gold and reports drift between them
+type ReconcileService struct {
+	connectors *connector.Registry
+	goldRepo   *repository.GoldRepository
+	batch      *BatchService
+	logger     *slog.Logger
+
+	mu sync.Mutex
+}
+
+func NewReconcileService(connectors *connector.Registry, goldRepo *repository.GoldRepository, batch *BatchService, logger *slog.Logger) *ReconcileService {
+	return &ReconcileService{
+		connectors: connectors,
+		goldRepo:   goldRepo,
+		batch:      batch,
+		logger:     logger,
+	}
+}
+
+// Reconcile checks the requested window and waits for the result.
+// It is a no-op if another reconciliation, or a sync that a repair would race, is running.
+func (s *ReconcileService) Reconcile(ctx context.Context, req ReconcileRequest) error {
+	_, run, err := s.Start(ctx, req)
+	if errors.Is(err, ErrReconcileInProgress) {
+		s.logger.Info("skipping reconciliation, another reconciliation is in progress")
+		return nil
+	}
+	if errors.Is(err, ErrSyncInProgress) {
+		s.logger.Info("skipping reconciliation repair, a sync is in progress")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return run()
+}
+
+// Start records a report per requested source. The returned function performs the
+// reconciliation; it must be called exactly once.
+func (s *ReconcileService) Start(ctx context.Context, req ReconcileRequest) ([]*model.ReconcileReport, func() error, error) {
+	if !req.From.Before(req.To) {
+		return nil, nil, errors.New("reconcile window is empty")
+	}
+
+	connectors := s.connectors.All()
+	if req.Source != nil {
+		c, ok := s.connectors.Get(*req.Source)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownSource, *req.Source)
+		}
+		connectors = []connector.Connector{c}
+	}
+
+	if !s.mu.TryLock() {
+		return nil, nil, ErrReconcileInProgress
+	}
+	// Repairs write to gold, so they must not interleave with a sync of the same rows
+	unlock := s.mu.Unlock
+	if req.Repair {
+		if !s.batch.mu.TryLock() {
+			s.mu.Unlock()
+			return nil, nil, ErrSyncInProgress
+		}
+		unlock = func() {
+			s.batch.mu.Unlock()
+			s.mu.Unlock()
+		}
+	}
+
+	reports := make([]*model.ReconcileReport, 0, len(connectors))
+	for _, c := range connectors {
+		report := &model.ReconcileReport{
+			Source:     c.Source(),
+			WindowFrom: req.From,
+			WindowTo:   req.To,
+			Repair:     req.Repair,
+		}
+		var err error
+		report.ID, err = s.goldRepo.CreateReconcileReport(ctx, report)
+		if err != nil {
+			unlock()
+			return nil, nil, fmt.Errorf("failed to record reconcile report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+
+	run := func() error {
+		defer unlock()
+
+		var errs []error
+		for i, c := range connectors {
+			if err := s.reconcileSource(ctx, c, reports[i]); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", c.Source(), err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+	return reports, run, nil
+}
+
+// ListReports returns paginated reconciliation reports, newest first
+func (s *ReconcileService) ListReports(ctx context.Context, filter model.ReconcileReportFilter) (*model.ReconcileReportListResponse, error) {
+	// Set defaults
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	reports, total, err := s.goldRepo.ListReconcileReports(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ReconcileReportListResponse{
+		Data: reports,
+		Pagination: model.Pagination{
+			Page:  filter.Page,
+			Limit: filter.Limit,
+			Total: total,
+		},
+	}, nil
+}
+
+// GetReport returns a reconciliation report with its drift items
+func (s *ReconcileService) GetReport(ctx context.Context, id int64) (*model.ReconcileReport, error) {
+	return s.goldRepo.GetReconcileReport(ctx, id)
+}
+
+func (s *ReconcileService) reconcileSource(ctx context.Context, c connector.Connector, report *model.ReconcileReport) error {
+	start := time.Now()
+
+	err := s.compare(ctx, c, report)
+
+	report.Status = model.ReconcileSucceeded
+	if err != nil {
+		msg := err.Error()
+		report.Status = model.ReconcileFailed
+		report.Error = &msg
+		s.logger.Error("reconciliation failed", "source", report.Source, "error", err)
+	}
+
+	if ferr := s.goldRepo.FinishReconcileReport(context.WithoutCancel(ctx), report); ferr != nil {
+		s.logger.Warn("failed to update reconcile report", "source", report.Source, "report_id", report.ID, "error", ferr)
+	}
+
+	s.logger.Info("reconciliation completed",
+		"source", report.Source,
+		"duration", time.Since(start),
+		"silver_count", report.SilverCount,
+		"gold_count", report.GoldCount,
+		"missing", report.MissingCount,
+		"extra", report.ExtraCount,
+		"stale", report.StaleCount,
+		"repaired", report.RepairedCount,
+	)
+
+	return err
+}
+
+// compare walks the silver window page by page, then checks gold rows in the same
+// window that silver did not return
+func (s *ReconcileService) compare(ctx context.Context, c connector.Connector, report *model.ReconcileReport) error {
+	source := c.Source()
+	seen := make(map[string]struct{})
+
+	cursor := &model.SyncCursor{UpdatedAt: report.WindowFrom}
+	for {
+		batch, err := c.FetchSince(ctx, cursor, batchSize)
+		if err != nil {
+			return err
+		}
+
+		inWindow := batch.News
+		for i, n := range batch.News {
+			if !n.SourceUpdatedAt.Before(report.WindowTo) {
+				inWindow = batch.News[:i]
+				break
+			}
+		}
+		if len(inWindow) == 0 {
+			break
+		}
+
+		if err := s.compareBatch(ctx, source, inWindow, report, seen); err != nil {
+			return err
+		}
+
+		if len(inWindow) < batchSize {
+			break
+		}
+		next := batch.Next
+		cursor = &next
+	}
+
+	goldUpdated, err := s.goldRepo.GetLiveNewsUpdatedBetween(ctx, source, report.WindowFrom, report.WindowTo)
+	if err != nil {
+		return err
+	}
+	report.GoldCount = len(goldUpdated)
+
+	var unseen []string
+	for id := range goldUpdated {
+		if _, ok := seen[id]; !ok {
+			unseen = append(unseen, id)
+		}
+	}
+
+	existing, err := s.existingInSilver(ctx, c, unseen)
+	if err != nil {
+		return err
+	}
+
+	var extra []model.DriftItem
+	var extraIDs []string
+	for _, id := range unseen {
+		// Still in silver but updated after the window: checked by the window that covers it
+		if _, ok := existing[id]; ok {
+			continue
+		}
+		goldUpdatedAt := goldUpdated[id]
+		extra = append(extra, model.DriftItem{SourceNewsID: id, Kind: model.DriftExtra, GoldUpdatedAt: &goldUpdatedAt})
+		extraIDs = append(extraIDs, id)
+	}
+
+	if report.Repair && len(extraIDs) > 0 {
+		for start := 0; start < len(extraIDs); start += retractionChunkSize {
+			end := min(start+retractionChunkSize, len(extraIDs))
+			count, err := s.goldRepo.MarkNewsDeleted(ctx, source, extraIDs[start:end])
+			if err != nil {
+				return fmt.Errorf("failed to repair drift: %w", err)
+			}
+			report.RepairedCount += count
+		}
+		for i := range extra {
+			extra[i].Repaired = true
+		}
+	}
+
+	for _, item := range extra {
+		s.addDrift(report, item)
+	}
+	return nil
+}
+
+func (s *ReconcileService) compareBatch(ctx context.Context, source model.NewsSource, silver []*model.TranslatedNews, report *model.ReconcileReport, seen map[string]struct{}) error {
+	ids := make([]string, len(silver))
+	for i, n := range silver {
+		ids[i] = n.SourceNewsID
+		seen[n.SourceNewsID] = struct{}{}
+	}
+	report.SilverCount += len(silver)
+
+	gold, err := s.goldRepo.GetLiveNewsBySourceIDs(ctx, source, ids)
+	if err != nil {
+		return err
+	}
+
+	var drifted []model.DriftItem
+	var repair []*model.TranslatedNews
+	for _, n := range silver {
+		item := model.DriftItem{SourceNewsID: n.SourceNewsID, SilverUpdatedAt: n.SourceUpdatedAt}
+
+		g, ok := gold[n.SourceNewsID]
+		switch {
+		case !ok:
+			item.Kind = model.DriftMissing
+		// Gold already holds a later write than the silver page read here
+		case g.SourceUpdatedAt != nil && n.SourceUpdatedAt != nil && g.SourceUpdatedAt.After(*n.SourceUpdatedAt):
+			continue
+		// Silver bumps updated_at on every change, so a newer silver row means gold
+		// missed a write; a different hash at the same time means gold content drifted
+		// without one, e.g. rows rewritten by a migration
+		case g.SourceUpdatedAt == nil || n.SourceUpdatedAt == nil || n.SourceUpdatedAt.After(*g.SourceUpdatedAt),
+			g.ContentHash == nil || *g.ContentHash != n.ContentHash():
+			item.Kind = model.DriftStale
+			item.GoldUpdatedAt = g.SourceUpdatedAt
+		default:
+			continue
+		}
+
+		drifted = append(drifted, item)
+		repair = append(repair, n)
+	}
+
+	if report.Repair && len(repair) > 0 {
+		if _, err := s.goldRepo.UpsertNews(ctx, repair); err != nil {
+			return fmt.Errorf("failed to repair drift: %w", err)
+		}
+		for i := range drifted {
+			drifted[i].Repaired = true
+		}
+		report.RepairedCount += len(repair)
+	}
+
+	for _, item := range drifted {
+		s.addDrift(report, item)
+	}
+	return nil
+}
+
+// existingInSilver returns which of ids still exist in silver. Connectors that cannot
+// answer are treated as if none of the ids exist.
+func (s *ReconcileService) existingInSilver(ctx context.Context, c connector.Connector, ids []string) (map[string]struct{}, error) {
+	existing := make(map[string]struct{})
+	rc, ok := c.(connector.Retractable)
+	if !ok {
+		return existing, nil
+	}
+
+	for start := 0; start < len(ids); start += retractionChunkSize {
+		end := min(start+retractionChunkSize, len(ids))
+		found, err := rc.ExistingIDs(ctx, ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range found {
+			existing[id] = struct{}{}
+		}
+	}
+	return existing, nil
+}
+
+func (s *ReconcileService) addDrift(report *model.ReconcileReport, item model.DriftItem) {
+	switch item.Kind {
+	case model.DriftMissing:
+		report.MissingCount++
+	case model.DriftExtra:
+		report.ExtraCount++
+	case model.DriftStale:
+		report.StaleCount++
+	}
+	report.Drift = append(report.Drift, item)
+}
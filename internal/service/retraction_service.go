@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/connector"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+const retractionChunkSize = 1000
+
+// RetractionService soft-deletes gold news whose source row was removed from silver.
+// Rows still present in silver are kept, since silver does not flag retractions.
+type RetractionService struct {
+	connectors *connector.Registry
+	goldRepo   *repository.GoldRepository
+	logger     *slog.Logger
+}
+
+func NewRetractionService(connectors *connector.Registry, goldRepo *repository.GoldRepository, logger *slog.Logger) *RetractionService {
+	return &RetractionService{
+		connectors: connectors,
+		goldRepo:   goldRepo,
+		logger:     logger,
+	}
+}
+
+// SweepAll checks every live gold row against silver for each source that supports it
+func (s *RetractionService) SweepAll(ctx context.Context) error {
+	s.logger.Info("starting retraction sweep")
+	start := time.Now()
+
+	attrs := []any{}
+	var errs []error
+	for _, c := range s.connectors.All() {
+		rc, ok := c.(connector.Retractable)
+		if !ok {
+			continue
+		}
+
+		count, err := s.sweep(ctx, rc)
+		if err != nil {
+			s.logger.Error("failed to sweep retractions", "source", c.Source(), "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", c.Source(), err))
+		}
+		attrs = append(attrs, string(c.Source())+"_retracted", count)
+	}
+	s.logger.Info("retraction sweep completed", append([]any{"duration", time.Since(start)}, attrs...)...)
+
+	return errors.Join(errs...)
+}
+
+func (s *RetractionService) sweep(ctx context.Context, c connector.Retractable) (int, error) {
+	source := c.Source()
+	retracted := 0
+	after := ""
+
+	for {
+		ids, err := s.goldRepo.ListLiveSourceNewsIDs(ctx, source, after, retractionChunkSize)
+		if err != nil {
+			return retracted, err
+		}
+		if len(ids) == 0 {
+			return retracted, nil
+		}
+
+		existing, err := c.ExistingIDs(ctx, ids)
+		if err != nil {
+			return retracted, err
+		}
+
+		live := make(map[string]struct{}, len(existing))
+		for _, id := range existing {
+			live[id] = struct{}{}
+		}
+		var missing []string
+		for _, id := range ids {
+			if _, ok := live[id]; !ok {
+				missing = append(missing, id)
+			}
+		}
+
+		if len(missing) > 0 {
+			count, err := s.goldRepo.MarkNewsDeleted(ctx, source, missing)
+			if err != nil {
+				return retracted, err
+			}
+			retracted += count
+			s.logger.Debug("marked news retracted", "source", source, "count", count)
+		}
+
+		after = ids[len(ids)-1]
+		if len(ids) < retractionChunkSize {
+			return retracted, nil
+		}
+	}
+}
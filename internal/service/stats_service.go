@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+// ErrUnknownTimezone is returned for time zones Postgres cannot bucket by
+var ErrUnknownTimezone = errors.New("unknown time zone")
+
+// StatsService handles ticker and topic aggregations over live news
+type StatsService struct {
+	goldRepo      *repository.GoldRepository
+	tickerService *TickerService
+
+	timezones sync.Map // zone name -> bool, whether Postgres knows it
+}
+
+func NewStatsService(goldRepo *repository.GoldRepository, tickerService *TickerService) *StatsService {
+	return &StatsService{goldRepo: goldRepo, tickerService: tickerService}
+}
+
+// setStatsDefaults clamps the number of rows returned by an aggregation
+func setStatsDefaults(filter *model.StatsFilter) {
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+}
+
+// TopTickers returns the most mentioned tickers in a window
+func (s *StatsService) TopTickers(ctx context.Context, filter model.StatsFilter) (*model.TickerStatsResponse, error) {
+	setStatsDefaults(&filter)
+
+	data, err := s.goldRepo.CountTickers(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		data = []model.TickerCount{}
+	}
+	return &model.TickerStatsResponse{From: filter.From, To: filter.To, Data: data}, nil
+}
+
+// TopTopics returns the most used topics in a window
+func (s *StatsService) TopTopics(ctx context.Context, filter model.StatsFilter) (*model.TopicStatsResponse, error) {
+	setStatsDefaults(&filter)
+
+	data, err := s.goldRepo.CountTopics(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		data = []model.TopicCount{}
+	}
+	return &model.TopicStatsResponse{From: filter.From, To: filter.To, Data: data}, nil
+}
+
+// TickerHistogram returns news volume of a ticker per hour or day, including empty buckets
+func (s *StatsService) TickerHistogram(ctx context.Context, filter model.HistogramFilter) (*model.TickerHistogramResponse, error) {
+	if err := s.checkTimezone(ctx, filter.Location); err != nil {
+		return nil, err
+	}
+	if tickers := s.tickerService.Normalize(ctx, []string{filter.Ticker}); len(tickers) > 0 {
+		filter.Ticker = tickers[0]
+	}
+
+	counts, err := s.goldRepo.CountTickerNewsByBucket(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := []model.HistogramBucket{}
+	for start := truncateLocal(filter.From, filter.Interval, filter.Location); start.Before(filter.To); start = nextBucket(start, filter.Interval) {
+		buckets = append(buckets, model.HistogramBucket{Start: start, Count: counts[start.Unix()]})
+	}
+
+	return &model.TickerHistogramResponse{
+		Ticker:   filter.Ticker,
+		Interval: filter.Interval,
+		Timezone: filter.Location.String(),
+		From:     filter.From,
+		To:       filter.To,
+		Buckets:  buckets,
+	}, nil
+}
+
+// checkTimezone rejects zones that Go loaded but Postgres does not know, such as
+// Local or zones missing from the server's tzdata. Answers are cached.
+func (s *StatsService) checkTimezone(ctx context.Context, loc *time.Location) error {
+	name := loc.String()
+	if name == "Local" {
+		return ErrUnknownTimezone
+	}
+	known, ok := s.timezones.Load(name)
+	if !ok {
+		exists, err := s.goldRepo.TimezoneExists(ctx, name)
+		if err != nil {
+			return err
+		}
+		s.timezones.Store(name, exists)
+		known = exists
+	}
+	if !known.(bool) {
+		return ErrUnknownTimezone
+	}
+	return nil
+}
+
+// truncateLocal returns the start of the bucket containing t, on the wall clock of loc
+func truncateLocal(t time.Time, interval model.StatsInterval, loc *time.Location) time.Time {
+	t = t.In(loc)
+	if interval == model.IntervalDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+}
+
+// nextBucket steps by calendar day so buckets stay aligned across DST changes
+func nextBucket(start time.Time, interval model.StatsInterval) time.Time {
+	if interval == model.IntervalDay {
+		return start.AddDate(0, 0, 1)
+	}
+	return start.Add(time.Hour)
+}
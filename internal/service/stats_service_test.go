@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/repository"
+	"github.com/onelineai/hana-news-api/internal/testdb"
+)
+
+func TestCheckTimezone(t *testing.T) {
+	ctx := context.Background()
+
+	// Local never reaches the database
+	s := NewStatsService(nil, nil)
+	if err := s.checkTimezone(ctx, time.Local); !errors.Is(err, ErrUnknownTimezone) {
+		t.Fatalf("Local: err = %v, want ErrUnknownTimezone", err)
+	}
+
+	s = NewStatsService(repository.NewGoldRepository(testdb.New(t)), nil)
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		t.Skip(err)
+	}
+	if err := s.checkTimezone(ctx, seoul); err != nil {
+		t.Fatalf("Asia/Seoul: %v", err)
+	}
+	// A zone Go accepts from a custom tzdata but Postgres does not know
+	if err := s.checkTimezone(ctx, time.FixedZone("Mars/Olympus", 0)); !errors.Is(err, ErrUnknownTimezone) {
+		t.Fatalf("Mars/Olympus: err = %v, want ErrUnknownTimezone", err)
+	}
+	if _, ok := s.timezones.Load("Mars/Olympus"); !ok {
+		t.Fatal("answer for Mars/Olympus was not cached")
+	}
+}
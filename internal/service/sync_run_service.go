@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+// SyncRunService handles sync run history queries
+type SyncRunService struct {
+	goldRepo *repository.GoldRepository
+}
+
+func NewSyncRunService(goldRepo *repository.GoldRepository) *SyncRunService {
+	return &SyncRunService{goldRepo: goldRepo}
+}
+
+// ListRuns returns paginated sync runs, newest first
+func (s *SyncRunService) ListRuns(ctx context.Context, filter model.SyncRunFilter) (*model.SyncRunListResponse, error) {
+	// Set defaults
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	runs, total, err := s.goldRepo.ListSyncRuns(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SyncRunListResponse{
+		Data: runs,
+		Pagination: model.Pagination{
+			Page:  filter.Page,
+			Limit: filter.Limit,
+			Total: total,
+		},
+	}, nil
+}
+
+// GetRun returns a sync run by ID
+func (s *SyncRunService) GetRun(ctx context.Context, id int64) (*model.SyncRun, error) {
+	return s.goldRepo.GetSyncRun(ctx, id)
+}
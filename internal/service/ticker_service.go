@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+// tickerCacheTTL bounds how long edits to ISINs and aliases take to show up
+const tickerCacheTTL = 10 * time.Minute
+
+// TickerService normalizes tickers and resolves them against gold.instruments.
+// Sync registers every ticker it sees there, so only the ISINs and aliases of
+// master rows are cached, reloaded after tickerCacheTTL.
+type TickerService struct {
+	goldRepo *repository.GoldRepository
+	logger   *slog.Logger
+
+	loadMu   sync.Mutex // serializes reloads so only one query runs at a time
+	mu       sync.RWMutex
+	aliases  map[string]string // ISIN or alias -> canonical ticker
+	loadedAt time.Time
+}
+
+func NewTickerService(goldRepo *repository.GoldRepository, logger *slog.Logger) *TickerService {
+	return &TickerService{goldRepo: goldRepo, logger: logger}
+}
+
+// Normalize maps user-supplied tickers to canonical codes. ISINs and aliases from
+// the mapping table take precedence over the suffix rules in model.NormalizeTicker.
+func (s *TickerService) Normalize(ctx context.Context, raw []string) []string {
+	s.refresh(ctx)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, r := range raw {
+		t, ok := s.aliases[strings.ToUpper(strings.TrimSpace(r))]
+		if !ok {
+			t = model.NormalizeTicker(r)
+		}
+		if _, dup := seen[t]; t == "" || dup {
+			continue
+		}
+		seen[t] = struct{}{}
+		out = append(out, t)
+	}
+	return out
+}
+
+// Describe returns instrument details for canonical tickers. Tickers missing from
+// the mapping table, or all of them if the lookup fails, only carry the exchange
+// implied by their suffix.
+func (s *TickerService) Describe(ctx context.Context, tickers []string) []model.Instrument {
+	known := make(map[string]model.Instrument, len(tickers))
+	if len(tickers) > 0 {
+		list, err := s.goldRepo.GetInstruments(ctx, tickers)
+		if err != nil {
+			s.logger.Error("failed to load instruments", "error", err)
+		}
+		for _, inst := range list {
+			known[inst.Ticker] = inst
+		}
+	}
+
+	out := make([]model.Instrument, 0, len(tickers))
+	for _, t := range tickers {
+		inst, ok := known[t]
+		if !ok {
+			inst = model.Instrument{Ticker: t, Exchange: model.TickerExchange(t)}
+		}
+		out = append(out, inst)
+	}
+	return out
+}
+
+// refresh reloads the ISINs and aliases once the cache has expired. The query runs
+// without holding mu so lookups keep serving the previous table meanwhile; load
+// errors are logged and the previous table stays in place.
+func (s *TickerService) refresh(ctx context.Context) {
+	if s.fresh() {
+		return
+	}
+
+	s.loadMu.Lock()
+	defer s.loadMu.Unlock()
+	if s.fresh() {
+		return
+	}
+
+	aliases, err := s.goldRepo.ListInstrumentAliases(ctx)
+	if err != nil {
+		s.logger.Error("failed to load instrument aliases", "error", err)
+		// Retry after a short delay rather than on every request
+		s.mu.Lock()
+		s.loadedAt = time.Now().Add(-tickerCacheTTL + time.Minute)
+		s.mu.Unlock()
+		return
+	}
+
+	s.mu.Lock()
+	s.aliases, s.loadedAt = aliases, time.Now()
+	s.mu.Unlock()
+}
+
+func (s *TickerService) fresh() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return time.Since(s.loadedAt) < tickerCacheTTL
+}
@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// ErrInvalidToken is returned for bearer tokens that fail verification or grant nothing
+var ErrInvalidToken = errors.New("invalid token")
+
+const (
+	// jwksMinReload throttles JWKS reloads triggered by unknown key IDs
+	jwksMinReload = time.Minute
+	// jwksMaxSize bounds the JWKS document read from a URL
+	jwksMaxSize = 1 << 20
+	// jwtLeeway tolerates clock skew with the token issuer
+	jwtLeeway = 30 * time.Second
+)
+
+// TokenService authenticates bearer JWTs signed with RS256 or ES256 against a JWKS
+// loaded from a file or URL. Requests are verified against cached keys only; a
+// background worker reloads them every JWKSRefresh, or sooner when a token names an
+// unknown key ID, so issuer key rotation needs no restart.
+type TokenService struct {
+	cfg    config.JWTConfig
+	client *http.Client
+	parser *jwt.Parser
+	logger *slog.Logger
+	// reload asks the background worker for an early reload
+	reload chan struct{}
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	keys []signingKey
+}
+
+// signingKey is a public key of the JWKS
+type signingKey struct {
+	kid string
+	key jwt.VerificationKey
+}
+
+func NewTokenService(cfg config.JWTConfig, logger *slog.Logger) *TokenService {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(jwtLeeway),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &TokenService{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		parser: jwt.NewParser(opts...),
+		logger: logger,
+		reload: make(chan struct{}, 1),
+	}
+}
+
+// Load reads the JWKS. Call it at startup so that a missing or malformed key set fails fast.
+func (s *TokenService) Load(ctx context.Context) error {
+	keys, err := s.fetchKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+	return nil
+}
+
+// Start reloads the JWKS in the background until the context is cancelled
+func (s *TokenService) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop waits for the background reloads to end after the context is cancelled
+func (s *TokenService) Stop() {
+	s.wg.Wait()
+}
+
+func (s *TokenService) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	var refresh <-chan time.Time
+	if s.cfg.JWKSRefresh > 0 {
+		ticker := time.NewTicker(s.cfg.JWKSRefresh)
+		defer ticker.Stop()
+		refresh = ticker.C
+	}
+	lastReload := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-refresh:
+		case <-s.reload:
+			// Tokens with made-up key IDs must not turn into a stream of fetches
+			if wait := jwksMinReload - time.Since(lastReload); wait > 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(wait):
+				}
+			}
+		}
+
+		s.refresh(ctx)
+		lastReload = time.Now()
+	}
+}
+
+// refresh reloads the JWKS, keeping the cached keys if that fails
+func (s *TokenService) refresh(ctx context.Context) {
+	keys, err := s.fetchKeys(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Warn("failed to reload JWKS, keeping cached keys", "error", err)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+// Authenticate verifies a bearer token and maps its claims to a client. Tokens without
+// the countries claim are rejected unless AllowAllCountries is set. The client takes
+// the configured ClientID, so token callers share that client's rate limit and usage
+// account; without one they are neither rate limited per client nor metered.
+func (s *TokenService) Authenticate(ctx context.Context, token string) (*model.APIClient, error) {
+	claims := jwt.MapClaims{}
+	_, err := s.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		keys := s.verificationKeys(kid)
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("unknown key ID %q", kid)
+		}
+		return jwt.VerificationKeySet{Keys: keys}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	subject, _ := claims.GetSubject()
+	client := &model.APIClient{ID: s.cfg.ClientID, Name: subject}
+
+	if values, ok := claimStrings(claims, s.cfg.CountriesClaim); ok {
+		client.Countries = []model.CountryCode{}
+		for _, v := range values {
+			cc := model.CountryCode(strings.ToUpper(v))
+			if (cc == model.CountryJP || cc == model.CountryCN) && !slices.Contains(client.Countries, cc) {
+				client.Countries = append(client.Countries, cc)
+			}
+		}
+		// An empty list would mean every source
+		if len(client.Countries) == 0 {
+			return nil, fmt.Errorf("%w: %s claim grants no countries", ErrInvalidToken, s.cfg.CountriesClaim)
+		}
+	} else if !s.cfg.AllowAllCountries {
+		return nil, fmt.Errorf("%w: missing %s claim", ErrInvalidToken, s.cfg.CountriesClaim)
+	}
+
+	if roles, ok := claimStrings(claims, s.cfg.RolesClaim); ok {
+		client.Admin = slices.Contains(roles, s.cfg.AdminRole)
+	}
+	return client, nil
+}
+
+// verificationKeys returns the cached keys that may have signed a token with the given
+// key ID (all keys if it has none). An unknown ID asks the worker for a reload; the
+// token itself is rejected, so a caller whose issuer rotated keys retries after it.
+func (s *TokenService) verificationKeys(kid string) []jwt.VerificationKey {
+	s.mu.Lock()
+	keys := s.keys
+	s.mu.Unlock()
+
+	if !hasKeyID(keys, kid) {
+		select {
+		case s.reload <- struct{}{}:
+		default:
+		}
+	}
+
+	var matched []jwt.VerificationKey
+	for _, k := range keys {
+		if kid == "" || k.kid == kid {
+			matched = append(matched, k.key)
+		}
+	}
+	return matched
+}
+
+// fetchKeys reads and parses the JWKS from the configured file or URL
+func (s *TokenService) fetchKeys(ctx context.Context) ([]signingKey, error) {
+	var data []byte
+	var err error
+	if s.cfg.JWKSFile != "" {
+		data, err = os.ReadFile(s.cfg.JWKSFile)
+	} else {
+		data, err = s.download(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS has no RS256 or ES256 signing keys")
+	}
+	return keys, nil
+}
+
+func (s *TokenService) download(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.JWKSURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS endpoint returned %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, jwksMaxSize))
+}
+
+// jsonWebKey is an entry of a JWKS (RFC 7517)
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS returns the RSA and P-256 signing keys of a JWKS, skipping other keys
+func parseJWKS(data []byte) ([]signingKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	var keys []signingKey
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		var key jwt.VerificationKey
+		switch {
+		case k.Kty == "RSA" && (k.Alg == "" || k.Alg == jwt.SigningMethodRS256.Alg()):
+			n, err := decodeJWKInt(k.N)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JWKS key %q: %w", k.Kid, err)
+			}
+			e, err := decodeJWKInt(k.E)
+			if err != nil || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+				return nil, fmt.Errorf("invalid JWKS key %q: bad exponent", k.Kid)
+			}
+			key = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case k.Kty == "EC" && k.Crv == "P-256" && (k.Alg == "" || k.Alg == jwt.SigningMethodES256.Alg()):
+			x, err := decodeJWKInt(k.X)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JWKS key %q: %w", k.Kid, err)
+			}
+			y, err := decodeJWKInt(k.Y)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JWKS key %q: %w", k.Kid, err)
+			}
+			key = &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		default:
+			continue
+		}
+		keys = append(keys, signingKey{kid: k.Kid, key: key})
+	}
+	return keys, nil
+}
+
+// decodeJWKInt decodes a base64url big-endian integer member of a JWK
+func decodeJWKInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("bad base64url integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// hasKeyID reports whether a key set holds a key with the given ID. Tokens without
+// a key ID match any key.
+func hasKeyID(keys []signingKey, kid string) bool {
+	if kid == "" {
+		return len(keys) > 0
+	}
+	for _, k := range keys {
+		if k.kid == kid {
+			return true
+		}
+	}
+	return false
+}
+
+// claimStrings reads a claim holding a string list or a space or comma separated
+// string. Dotted names descend into objects. Reports whether the claim is present.
+func claimStrings(claims jwt.MapClaims, name string) ([]string, bool) {
+	if name == "" {
+		return nil, false
+	}
+	var v any = map[string]any(claims)
+	for _, part := range strings.Split(name, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = obj[part]; !ok {
+			return nil, false
+		}
+	}
+
+	switch v := v.(type) {
+	case string:
+		return strings.FieldsFunc(v, func(r rune) bool { return r == ' ' || r == ',' }), true
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values, true
+	}
+	return nil, true
+}
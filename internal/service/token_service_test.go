@@ -0,0 +1,476 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/model"
+)
+
+// testSigner is a locally generated key that signs test tokens
+type testSigner struct {
+	kid    string
+	method jwt.SigningMethod
+	key    any
+	jwk    map[string]string
+}
+
+func newRSASigner(t *testing.T, kid string) testSigner {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testSigner{
+		kid:    kid,
+		method: jwt.SigningMethodRS256,
+		key:    key,
+		jwk: map[string]string{
+			"kty": "RSA", "kid": kid, "use": "sig", "alg": "RS256",
+			"n": b64(key.N.Bytes()),
+			"e": b64(big.NewInt(int64(key.E)).Bytes()),
+		},
+	}
+}
+
+func newECSigner(t *testing.T, kid string) testSigner {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testSigner{
+		kid:    kid,
+		method: jwt.SigningMethodES256,
+		key:    key,
+		jwk: map[string]string{
+			"kty": "EC", "kid": kid, "crv": "P-256",
+			"x": b64(key.X.FillBytes(make([]byte, 32))),
+			"y": b64(key.Y.FillBytes(make([]byte, 32))),
+		},
+	}
+}
+
+func (s testSigner) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(s.method, claims)
+	token.Header["kid"] = s.kid
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func jwksJSON(t *testing.T, signers ...testSigner) []byte {
+	t.Helper()
+	keys := make([]map[string]string, 0, len(signers))
+	for _, s := range signers {
+		keys = append(keys, s.jwk)
+	}
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// writeJWKS writes a JWKS file and returns its path
+func writeJWKS(t *testing.T, path string, signers ...testSigner) string {
+	t.Helper()
+	if path == "" {
+		path = filepath.Join(t.TempDir(), "jwks.json")
+	}
+	if err := os.WriteFile(path, jwksJSON(t, signers...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testJWTConfig(jwksFile string) config.JWTConfig {
+	return config.JWTConfig{
+		JWKSFile:       jwksFile,
+		Issuer:         "https://sso.hana.test",
+		Audience:       "hana-news-api",
+		CountriesClaim: "countries",
+		RolesClaim:     "realm_access.roles",
+		AdminRole:      "admin",
+		ClientID:       "8d1f0b7e-3c1a-4b8e-9a51-0f4c2a7d9e10",
+	}
+}
+
+func newTestTokenService(t *testing.T, cfg config.JWTConfig) *TokenService {
+	t.Helper()
+	s := NewTokenService(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := s.Load(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"sub":       "portal-user",
+		"iss":       "https://sso.hana.test",
+		"aud":       "hana-news-api",
+		"iat":       now.Unix(),
+		"exp":       now.Add(time.Hour).Unix(),
+		"countries": []string{"CN"},
+	}
+}
+
+func withClaims(changes map[string]any) jwt.MapClaims {
+	claims := validClaims()
+	for k, v := range changes {
+		if v == nil {
+			delete(claims, k)
+		} else {
+			claims[k] = v
+		}
+	}
+	return claims
+}
+
+func TestTokenServiceAuthenticate(t *testing.T) {
+	rsaSigner := newRSASigner(t, "rsa-1")
+	ecSigner := newECSigner(t, "ec-1")
+	unknownSigner := newRSASigner(t, "rsa-unknown")
+	jwksFile := writeJWKS(t, "", rsaSigner, ecSigner)
+
+	hsToken := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+	hsToken.Header["kid"] = "rsa-1"
+	hsSigned, err := hsToken.SignedString([]byte("shared-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	noneToken := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims())
+	noneToken.Header["kid"] = "rsa-1"
+	noneSigned, err := noneToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		token         string
+		allowAll      bool
+		wantErr       bool
+		wantCountries []model.CountryCode
+		wantAdmin     bool
+	}{
+		{
+			name:          "RS256",
+			token:         rsaSigner.sign(t, validClaims()),
+			wantCountries: []model.CountryCode{model.CountryCN},
+		},
+		{
+			name:          "ES256",
+			token:         ecSigner.sign(t, validClaims()),
+			wantCountries: []model.CountryCode{model.CountryCN},
+		},
+		{
+			name:    "expired",
+			token:   rsaSigner.sign(t, withClaims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})),
+			wantErr: true,
+		},
+		{
+			name:          "expired within leeway",
+			token:         rsaSigner.sign(t, withClaims(map[string]any{"exp": time.Now().Add(-10 * time.Second).Unix()})),
+			wantCountries: []model.CountryCode{model.CountryCN},
+		},
+		{
+			name:    "missing exp",
+			token:   rsaSigner.sign(t, withClaims(map[string]any{"exp": nil})),
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer",
+			token:   rsaSigner.sign(t, withClaims(map[string]any{"iss": "https://evil.test"})),
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			token:   rsaSigner.sign(t, withClaims(map[string]any{"aud": "other-api"})),
+			wantErr: true,
+		},
+		{
+			name:    "wrong alg HS256",
+			token:   hsSigned,
+			wantErr: true,
+		},
+		{
+			name:    "wrong alg none",
+			token:   noneSigned,
+			wantErr: true,
+		},
+		{
+			name:    "unknown kid",
+			token:   unknownSigner.sign(t, validClaims()),
+			wantErr: true,
+		},
+		{
+			name: "known kid with other key's signature",
+			token: func() string {
+				forged := unknownSigner
+				forged.kid = "rsa-1"
+				return forged.sign(t, validClaims())
+			}(),
+			wantErr: true,
+		},
+		{
+			name:          "countries as space separated string",
+			token:         rsaSigner.sign(t, withClaims(map[string]any{"countries": "jp cn"})),
+			wantCountries: []model.CountryCode{model.CountryJP, model.CountryCN},
+		},
+		{
+			name:          "unknown countries dropped",
+			token:         rsaSigner.sign(t, withClaims(map[string]any{"countries": []string{"US", "JP", "JP"}})),
+			wantCountries: []model.CountryCode{model.CountryJP},
+		},
+		{
+			name:    "countries grants nothing",
+			token:   rsaSigner.sign(t, withClaims(map[string]any{"countries": []string{"US"}})),
+			wantErr: true,
+		},
+		{
+			name:    "countries missing",
+			token:   rsaSigner.sign(t, withClaims(map[string]any{"countries": nil})),
+			wantErr: true,
+		},
+		{
+			name:     "countries missing with all sources allowed",
+			token:    rsaSigner.sign(t, withClaims(map[string]any{"countries": nil})),
+			allowAll: true,
+		},
+		{
+			name: "admin role in nested claim",
+			token: rsaSigner.sign(t, withClaims(map[string]any{
+				"realm_access": map[string]any{"roles": []string{"viewer", "admin"}},
+			})),
+			wantCountries: []model.CountryCode{model.CountryCN},
+			wantAdmin:     true,
+		},
+		{
+			name: "other roles only",
+			token: rsaSigner.sign(t, withClaims(map[string]any{
+				"realm_access": map[string]any{"roles": []string{"viewer"}},
+			})),
+			wantCountries: []model.CountryCode{model.CountryCN},
+		},
+		{
+			name:          "top-level roles ignored for nested claim",
+			token:         rsaSigner.sign(t, withClaims(map[string]any{"roles": []string{"admin"}})),
+			wantCountries: []model.CountryCode{model.CountryCN},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testJWTConfig(jwksFile)
+			cfg.AllowAllCountries = tt.allowAll
+			s := newTestTokenService(t, cfg)
+
+			client, err := s.Authenticate(context.Background(), tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("Authenticate() error = %v, want ErrInvalidToken", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if client.ID != cfg.ClientID || client.Name != "portal-user" {
+				t.Errorf("client = %q %q, want %q portal-user", client.ID, client.Name, cfg.ClientID)
+			}
+			if !slices.Equal(client.Countries, tt.wantCountries) {
+				t.Errorf("Countries = %v, want %v", client.Countries, tt.wantCountries)
+			}
+			if client.Admin != tt.wantAdmin {
+				t.Errorf("Admin = %v, want %v", client.Admin, tt.wantAdmin)
+			}
+		})
+	}
+}
+
+func TestTokenServiceRotation(t *testing.T) {
+	oldSigner := newRSASigner(t, "2026-01")
+	newSigner := newECSigner(t, "2026-02")
+	jwksFile := writeJWKS(t, "", oldSigner)
+	s := newTestTokenService(t, testJWTConfig(jwksFile))
+	ctx := context.Background()
+
+	if _, err := s.Authenticate(ctx, oldSigner.sign(t, validClaims())); err != nil {
+		t.Fatalf("old key before rotation: %v", err)
+	}
+
+	// The issuer publishes the new key; until the worker reloads, its tokens are
+	// rejected and an early reload is requested
+	writeJWKS(t, jwksFile, newSigner)
+	newToken := newSigner.sign(t, validClaims())
+	if _, err := s.Authenticate(ctx, newToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("new key before reload: error = %v, want ErrInvalidToken", err)
+	}
+	select {
+	case <-s.reload:
+	default:
+		t.Fatal("unknown key ID did not request a reload")
+	}
+
+	s.refresh(ctx)
+	if _, err := s.Authenticate(ctx, newToken); err != nil {
+		t.Fatalf("new key after reload: %v", err)
+	}
+	if _, err := s.Authenticate(ctx, oldSigner.sign(t, validClaims())); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("old key after rotation: error = %v, want ErrInvalidToken", err)
+	}
+
+	// A broken key set keeps the cached keys
+	if err := os.WriteFile(jwksFile, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s.refresh(ctx)
+	if _, err := s.Authenticate(ctx, newToken); err != nil {
+		t.Fatalf("cached key after failed reload: %v", err)
+	}
+}
+
+func TestTokenServiceUnknownKidDoesNotFetch(t *testing.T) {
+	signer := newRSASigner(t, "rsa-1")
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.Write(jwksJSON(t, signer))
+	}))
+	defer srv.Close()
+
+	cfg := testJWTConfig("")
+	cfg.JWKSURL = srv.URL
+	s := newTestTokenService(t, cfg)
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("fetches after Load = %d, want 1", got)
+	}
+
+	attacker := newRSASigner(t, "made-up")
+	for range 20 {
+		if _, err := s.Authenticate(context.Background(), attacker.sign(t, validClaims())); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("error = %v, want ErrInvalidToken", err)
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("fetches after unknown key IDs = %d, want 1 (reloads belong to the worker)", got)
+	}
+	if len(s.reload) != 1 {
+		t.Errorf("pending reload requests = %d, want 1", len(s.reload))
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaSigner := newRSASigner(t, "rsa-1")
+	ecSigner := newECSigner(t, "ec-1")
+
+	tests := []struct {
+		name     string
+		data     string
+		wantKids []string
+		wantErr  bool
+	}{
+		{
+			name:     "RSA and EC keys",
+			data:     string(jwksJSON(t, rsaSigner, ecSigner)),
+			wantKids: []string{"rsa-1", "ec-1"},
+		},
+		{
+			name:     "encryption keys skipped",
+			data:     `{"keys":[{"kty":"RSA","kid":"enc","use":"enc","n":"` + rsaSigner.jwk["n"] + `","e":"AQAB"}]}`,
+			wantKids: nil,
+		},
+		{
+			name:     "other algorithms skipped",
+			data:     `{"keys":[{"kty":"RSA","kid":"ps","alg":"PS256","n":"` + rsaSigner.jwk["n"] + `","e":"AQAB"}]}`,
+			wantKids: nil,
+		},
+		{
+			name:     "other curves skipped",
+			data:     `{"keys":[{"kty":"EC","kid":"p384","crv":"P-384","x":"AQ","y":"AQ"}]}`,
+			wantKids: nil,
+		},
+		{
+			name:     "symmetric keys skipped",
+			data:     `{"keys":[{"kty":"oct","kid":"hs","k":"c2VjcmV0"}]}`,
+			wantKids: nil,
+		},
+		{
+			name:     "padded base64 accepted",
+			data:     `{"keys":[{"kty":"RSA","kid":"padded","n":"` + rsaSigner.jwk["n"] + `==","e":"AQAB"}]}`,
+			wantKids: []string{"padded"},
+		},
+		{
+			name:    "malformed JSON",
+			data:    `{"keys":`,
+			wantErr: true,
+		},
+		{
+			name:    "bad modulus",
+			data:    `{"keys":[{"kty":"RSA","kid":"bad","n":"!!","e":"AQAB"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "bad exponent",
+			data:    `{"keys":[{"kty":"RSA","kid":"bad","n":"` + rsaSigner.jwk["n"] + `","e":"AQ"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing EC coordinate",
+			data:    `{"keys":[{"kty":"EC","kid":"bad","crv":"P-256","x":"` + ecSigner.jwk["x"] + `"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := parseJWKS([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("parseJWKS() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseJWKS() error = %v", err)
+			}
+			var kids []string
+			for _, k := range keys {
+				kids = append(kids, k.kid)
+			}
+			if !slices.Equal(kids, tt.wantKids) {
+				t.Errorf("kids = %v, want %v", kids, tt.wantKids)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+const (
+	// usageQueueSize bounds the events waiting to be written; further events are dropped
+	usageQueueSize = 10000
+	// usageBatchSize is the most events written in one round trip
+	usageBatchSize = 500
+	// usageFlushInterval is how long an event may wait for its batch to fill
+	usageFlushInterval = 2 * time.Second
+	// usageWriteAttempts is how often a batch is written before it is given up
+	usageWriteAttempts = 3
+)
+
+// UsageService meters client requests and builds monthly usage reports. Events are
+// written to gold in the background so handlers never wait for them.
+type UsageService struct {
+	goldRepo *repository.GoldRepository
+	logger   *slog.Logger
+
+	events  chan model.UsageEvent
+	dropped atomic.Int64
+	wg      sync.WaitGroup
+}
+
+func NewUsageService(goldRepo *repository.GoldRepository, logger *slog.Logger) *UsageService {
+	return &UsageService{
+		goldRepo: goldRepo,
+		logger:   logger,
+		events:   make(chan model.UsageEvent, usageQueueSize),
+	}
+}
+
+// Record queues a usage event. It never blocks; events are dropped while the queue is full.
+func (s *UsageService) Record(e model.UsageEvent) {
+	select {
+	case s.events <- e:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Start writes queued events in the background until ctx is cancelled
+func (s *UsageService) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop waits for the writer to end after the context is cancelled and writes the
+// remaining events. Call it once the HTTP server has drained.
+func (s *UsageService) Stop() {
+	s.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for {
+		batch := s.drain(nil)
+		if len(batch) == 0 {
+			return
+		}
+		if ctx.Err() != nil {
+			s.logger.Error("usage events not written before shutdown, events dropped", "dropped", len(batch)+len(s.events))
+			return
+		}
+		s.write(ctx, batch)
+	}
+}
+
+func (s *UsageService) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(usageFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]model.UsageEvent, 0, usageBatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			// Stop writes the rest
+			for _, e := range batch {
+				s.Record(e)
+			}
+			return
+		case e := <-s.events:
+			batch = append(batch, e)
+			if len(batch) < usageBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		s.write(ctx, s.drain(batch))
+		batch = batch[:0]
+	}
+}
+
+// drain appends queued events to batch up to usageBatchSize
+func (s *UsageService) drain(batch []model.UsageEvent) []model.UsageEvent {
+	for len(batch) < usageBatchSize {
+		select {
+		case e := <-s.events:
+			batch = append(batch, e)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// write stores a batch of events, retrying failed writes with a short backoff. Events
+// that are still not written, or that name clients which no longer exist, are logged
+// with their count; a database outage longer than the retries must not grow the queue
+// without bound.
+func (s *UsageService) write(ctx context.Context, batch []model.UsageEvent) {
+	if dropped := s.dropped.Swap(0); dropped > 0 {
+		s.logger.Error("usage queue full, events dropped", "dropped", dropped)
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	var err error
+	for attempt := 1; attempt <= usageWriteAttempts; attempt++ {
+		var stored int64
+		if stored, err = s.goldRepo.InsertUsageEvents(ctx, batch); err == nil {
+			if skipped := int64(len(batch)) - stored; skipped > 0 {
+				s.logger.Error("usage events of unknown clients dropped", "dropped", skipped, "events", len(batch))
+			}
+			return
+		}
+		if attempt < usageWriteAttempts {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+		if ctx.Err() != nil {
+			// Shutting down; Stop writes them with a fresh context
+			for _, e := range batch {
+				s.Record(e)
+			}
+			return
+		}
+	}
+	s.logger.Error("failed to write usage events, events dropped", "error", err, "dropped", len(batch))
+}
+
+// Report totals the usage of a month per client and endpoint
+func (s *UsageService) Report(ctx context.Context, filter model.UsageReportFilter) (*model.UsageReport, error) {
+	from, to := usageMonth(filter.Month)
+	rows, err := s.goldRepo.UsageReport(ctx, from, to, filter.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.UsageReport{
+		Month:    from.Format("2006-01"),
+		Timezone: billingLocation.String(),
+		Data:     rows,
+	}, nil
+}
+
+// ArticleReport lists the article details each client opened in a month
+func (s *UsageService) ArticleReport(ctx context.Context, filter model.UsageReportFilter) (*model.UsageArticleReport, error) {
+	from, to := usageMonth(filter.Month)
+	rows, err := s.goldRepo.UsageArticles(ctx, from, to, filter.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.UsageArticleReport{
+		Month:    from.Format("2006-01"),
+		Timezone: billingLocation.String(),
+		Data:     rows,
+	}, nil
+}
+
+// WriteUsageReportCSV writes a usage report as CSV with a header row
+func WriteUsageReportCSV(w io.Writer, report *model.UsageReport) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"month", "client_id", "client_name", "endpoint", "requests", "errors", "articles", "unique_articles"})
+	for _, row := range report.Data {
+		_ = cw.Write([]string{
+			report.Month, row.ClientID, row.ClientName, row.Endpoint,
+			strconv.FormatInt(row.Requests, 10), strconv.FormatInt(row.Errors, 10),
+			strconv.FormatInt(row.Articles, 10), strconv.FormatInt(row.UniqueArticles, 10),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteUsageArticleReportCSV writes an article report as CSV with a header row
+func WriteUsageArticleReportCSV(w io.Writer, report *model.UsageArticleReport) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"month", "client_id", "client_name", "news_id", "views", "first_viewed_at", "last_viewed_at"})
+	for _, row := range report.Data {
+		_ = cw.Write([]string{
+			report.Month, row.ClientID, row.ClientName, row.NewsID,
+			strconv.FormatInt(row.Views, 10),
+			row.FirstViewedAt.In(billingLocation).Format(time.RFC3339),
+			row.LastViewedAt.In(billingLocation).Format(time.RFC3339),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// usageMonth returns the bounds of the billing month containing t
+func usageMonth(t time.Time) (time.Time, time.Time) {
+	t = t.In(billingLocation)
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, billingLocation)
+	return from, from.AddDate(0, 1, 0)
+}
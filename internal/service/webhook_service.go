@@ -0,0 +1,551 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+)
+
+// ErrInvalidWebhook is returned for webhook settings that fail validation
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+const (
+	// maxWebhookTickers and maxWebhookTopics cap the filters of one webhook
+	maxWebhookTickers = 500
+	maxWebhookTopics  = 20
+	// maxWebhookBackoff caps the exponential retry delay
+	maxWebhookBackoff = 6 * time.Hour
+	// defaultWebhookPollInterval applies when the configured interval is not positive
+	defaultWebhookPollInterval = 30 * time.Second
+	// maxReplayWindow caps the publish time range of one replay
+	maxReplayWindow = 31 * 24 * time.Hour
+
+	// Headers of webhook requests. The signature is the hex HMAC-SHA256 of
+	// "<timestamp>.<body>" keyed with the webhook secret.
+	webhookIDHeader        = "X-Webhook-Id"
+	webhookEventHeader     = "X-Webhook-Event"
+	webhookTimestampHeader = "X-Webhook-Timestamp"
+	webhookSignatureHeader = "X-Webhook-Signature"
+
+	// sealedSecretPrefix marks secrets encrypted with WEBHOOK_SECRET_KEY; stored
+	// secrets without it are plaintext
+	sealedSecretPrefix = "enc:v1:"
+)
+
+// WebhookService manages webhook subscriptions and delivers newly synced news to them.
+// Deliveries are queued in gold, so any replica may send them and retries survive restarts.
+type WebhookService struct {
+	goldRepo      *repository.GoldRepository
+	tickerService *TickerService
+	cfg           config.WebhookConfig
+	client        *http.Client
+	logger        *slog.Logger
+
+	wake chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewWebhookService(goldRepo *repository.GoldRepository, tickerService *TickerService, cfg config.WebhookConfig, logger *slog.Logger) *WebhookService {
+	return &WebhookService{
+		goldRepo:      goldRepo,
+		tickerService: tickerService,
+		cfg:           cfg,
+		client:        &http.Client{Timeout: cfg.Timeout},
+		logger:        logger,
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// List returns paginated webhooks
+func (s *WebhookService) List(ctx context.Context, filter model.WebhookFilter) (*model.WebhookListResponse, error) {
+	// Set defaults
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	webhooks, total, err := s.goldRepo.ListWebhooks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WebhookListResponse{
+		Data: webhooks,
+		Pagination: model.Pagination{
+			Page:  filter.Page,
+			Limit: filter.Limit,
+			Total: total,
+		},
+	}, nil
+}
+
+// Get returns a webhook by ID, or nil if it does not exist
+func (s *WebhookService) Get(ctx context.Context, id string) (*model.Webhook, error) {
+	return s.goldRepo.GetWebhook(ctx, id)
+}
+
+// Create validates and stores a webhook. The returned webhook carries its secret,
+// which is generated if the request has none.
+func (s *WebhookService) Create(ctx context.Context, req model.WebhookRequest) (*model.Webhook, error) {
+	w := model.Webhook{Active: true}
+	if err := s.apply(ctx, &w, req); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = newWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	sealed, err := sealWebhookSecret(s.cfg.SecretKey, secret)
+	if err != nil {
+		return nil, err
+	}
+	w.Secret = sealed
+	if err := s.goldRepo.CreateWebhook(ctx, &w); err != nil {
+		return nil, err
+	}
+	w.Secret = secret
+	return &w, nil
+}
+
+// Update replaces a webhook's settings. Returns nil if the webhook does not exist.
+func (s *WebhookService) Update(ctx context.Context, id string, req model.WebhookRequest) (*model.Webhook, error) {
+	w, err := s.goldRepo.GetWebhook(ctx, id)
+	if err != nil || w == nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, w, req); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret != "" {
+		if secret, err = sealWebhookSecret(s.cfg.SecretKey, secret); err != nil {
+			return nil, err
+		}
+	}
+
+	found, err := s.goldRepo.UpdateWebhook(ctx, w, secret)
+	if err != nil || !found {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Delete removes a webhook with its pending deliveries. Returns false if it does not exist.
+func (s *WebhookService) Delete(ctx context.Context, id string) (bool, error) {
+	return s.goldRepo.DeleteWebhook(ctx, id)
+}
+
+// Replay queues matching news published between from and to for re-delivery.
+// Returns nil if the webhook does not exist.
+func (s *WebhookService) Replay(ctx context.Context, id string, req model.WebhookReplayRequest) (*model.WebhookReplayResponse, error) {
+	if req.From.IsZero() || req.To.IsZero() {
+		return nil, fmt.Errorf("%w: from and to are required", ErrInvalidWebhook)
+	}
+	if !req.From.Before(req.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidWebhook)
+	}
+	if req.To.Sub(req.From) > maxReplayWindow {
+		return nil, fmt.Errorf("%w: replay window must not exceed 31 days", ErrInvalidWebhook)
+	}
+
+	w, err := s.goldRepo.GetWebhook(ctx, id)
+	if err != nil || w == nil {
+		return nil, err
+	}
+
+	queued, err := s.goldRepo.EnqueueWebhookReplay(ctx, *w, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	if queued > 0 && w.Active {
+		s.Trigger()
+	}
+	return &model.WebhookReplayResponse{Queued: queued}, nil
+}
+
+// ListDeadLetters returns paginated deliveries of a webhook that failed all retries
+func (s *WebhookService) ListDeadLetters(ctx context.Context, filter model.WebhookDeadLetterFilter) (*model.WebhookDeadLetterListResponse, error) {
+	// Set defaults
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	letters, total, err := s.goldRepo.ListWebhookDeadLetters(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WebhookDeadLetterListResponse{
+		Data: letters,
+		Pagination: model.Pagination{
+			Page:  filter.Page,
+			Limit: filter.Limit,
+			Total: total,
+		},
+	}, nil
+}
+
+// apply validates a request and copies it onto w. Active is only changed if set.
+func (s *WebhookService) apply(ctx context.Context, w *model.Webhook, req model.WebhookRequest) error {
+	u, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	w.URL = u.String()
+
+	w.Country = nil
+	if req.Country != "" {
+		c := model.CountryCode(strings.ToUpper(req.Country))
+		if c != model.CountryJP && c != model.CountryCN {
+			return fmt.Errorf("%w: country must be 'JP' or 'CN'", ErrInvalidWebhook)
+		}
+		w.Country = &c
+	}
+
+	if len(req.Tickers) > maxWebhookTickers {
+		return fmt.Errorf("%w: at most %d tickers are allowed", ErrInvalidWebhook, maxWebhookTickers)
+	}
+	w.Tickers = s.tickerService.Normalize(ctx, req.Tickers)
+	if w.Tickers == nil {
+		w.Tickers = []string{}
+	}
+
+	w.Topics = []string{}
+	for _, t := range req.Topics {
+		if t = strings.TrimSpace(t); t != "" && !slices.Contains(w.Topics, t) {
+			w.Topics = append(w.Topics, t)
+		}
+	}
+	if len(w.Topics) > maxWebhookTopics {
+		return fmt.Errorf("%w: at most %d topics are allowed", ErrInvalidWebhook, maxWebhookTopics)
+	}
+
+	w.Description = req.Description
+	if req.Active != nil {
+		w.Active = *req.Active
+	}
+	return nil
+}
+
+// newWebhookSecret returns a random 256-bit signing key
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// Start runs the delivery worker until ctx is done. With a secret key configured,
+// secrets still stored in plaintext are encrypted first.
+func (s *WebhookService) Start(ctx context.Context) error {
+	if s.cfg.SecretKey == nil {
+		s.logger.Warn("WEBHOOK_SECRET_KEY not set, webhook secrets are stored in plaintext")
+	} else if err := s.sealStoredSecrets(ctx); err != nil {
+		return fmt.Errorf("failed to encrypt webhook secrets: %w", err)
+	}
+
+	s.wg.Add(1)
+	go s.run(ctx)
+	return nil
+}
+
+// sealStoredSecrets encrypts the plaintext secrets left from before the key was set
+func (s *WebhookService) sealStoredSecrets(ctx context.Context) error {
+	secrets, err := s.goldRepo.ListPlaintextWebhookSecrets(ctx, sealedSecretPrefix)
+	if err != nil {
+		return err
+	}
+	for id, secret := range secrets {
+		sealed, err := sealWebhookSecret(s.cfg.SecretKey, secret)
+		if err != nil {
+			return err
+		}
+		if err := s.goldRepo.ReplaceWebhookSecret(ctx, id, secret, sealed); err != nil {
+			return err
+		}
+	}
+	if len(secrets) > 0 {
+		s.logger.Info("encrypted stored webhook secrets", "count", len(secrets))
+	}
+	return nil
+}
+
+// Stop waits for the delivery worker to finish after its context is cancelled
+func (s *WebhookService) Stop() {
+	s.wg.Wait()
+}
+
+// Trigger wakes the delivery worker; bursts of triggers coalesce into one pass
+func (s *WebhookService) Trigger() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run queues and delivers news on trigger or poll
+func (s *WebhookService) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	interval := s.cfg.PollInterval
+	if interval <= 0 {
+		interval = defaultWebhookPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.enqueue(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("failed to queue webhook deliveries", "error", err)
+		}
+		if err := s.deliverDue(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("failed to deliver webhooks", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// enqueue queues news written since each active webhook was last queued
+func (s *WebhookService) enqueue(ctx context.Context) error {
+	webhooks, err := s.goldRepo.ListActiveWebhooks(ctx)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	head, err := s.goldRepo.GetLatestNewsSeq(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, w := range webhooks {
+		queued, err := s.goldRepo.EnqueueWebhookDeliveries(ctx, w, head)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", w.ID, err))
+			continue
+		}
+		if queued > 0 {
+			s.logger.Debug("queued webhook deliveries", "webhook_id", w.ID, "count", queued)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliverDue sends due deliveries until none are left
+func (s *WebhookService) deliverDue(ctx context.Context) error {
+	batch := max(s.cfg.Workers, 1)
+	// A claimed batch is sent in parallel, so each delivery finishes within one timeout
+	lease := s.cfg.Timeout + time.Minute
+
+	for ctx.Err() == nil {
+		deliveries, err := s.goldRepo.ClaimWebhookDeliveries(ctx, batch, lease)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		for _, d := range deliveries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.deliver(ctx, d)
+			}()
+		}
+		wg.Wait()
+
+		if len(deliveries) < batch {
+			return nil
+		}
+	}
+	return nil
+}
+
+// deliver sends one delivery and records its outcome
+func (s *WebhookService) deliver(ctx context.Context, d model.WebhookDelivery) {
+	logger := s.logger.With("webhook_id", d.Webhook.ID, "delivery_id", d.ID, "attempt", d.Attempts)
+
+	var err error
+	if d.News == nil {
+		// Retracted since it was queued; receivers learn about retractions from GET /v1/news/:id
+		err = s.goldRepo.CompleteWebhookDelivery(ctx, d.ID)
+	} else if status, sendErr := s.send(ctx, d); sendErr == nil {
+		err = s.goldRepo.CompleteWebhookDelivery(ctx, d.ID)
+	} else if ctx.Err() != nil {
+		// Shutting down; the lease expires and another attempt follows
+		return
+	} else if d.Attempts >= s.cfg.MaxAttempts {
+		logger.Warn("webhook delivery failed permanently", "error", sendErr)
+		err = s.goldRepo.DeadLetterWebhookDelivery(ctx, d.ID, status, sendErr.Error())
+	} else {
+		backoff := webhookBackoff(s.cfg.RetryBackoff, d.Attempts)
+		logger.Info("webhook delivery failed, retrying", "error", sendErr, "backoff", backoff)
+		err = s.goldRepo.RetryWebhookDelivery(ctx, d.ID, time.Now().Add(backoff), status, sendErr.Error())
+	}
+	if err != nil && ctx.Err() == nil {
+		logger.Error("failed to record webhook delivery", "error", err)
+	}
+}
+
+// webhookBackoff returns the wait after a delivery's attempts-th failure: base,
+// doubled for each further failure, up to maxWebhookBackoff
+func webhookBackoff(base time.Duration, attempts int) time.Duration {
+	return min(base<<min(max(attempts-1, 0), 20), maxWebhookBackoff)
+}
+
+// send posts a signed delivery and returns the response status, if any
+func (s *WebhookService) send(ctx context.Context, d model.WebhookDelivery) (*int, error) {
+	secret, err := openWebhookSecret(s.cfg.SecretKey, d.Webhook.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	news := d.News.Item
+	for _, t := range d.News.Tickers {
+		if slices.Contains(d.Webhook.Tickers, t) {
+			news.MatchedTickers = append(news.MatchedTickers, t)
+		}
+	}
+
+	body, err := json.Marshal(model.WebhookPayload{
+		ID:        strconv.FormatInt(d.ID, 10),
+		Event:     d.Event,
+		WebhookID: d.Webhook.ID,
+		CreatedAt: d.CreatedAt,
+		Seq:       d.News.Seq,
+		News:      news,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "hana-news-api-webhook/1.0")
+	req.Header.Set(webhookIDHeader, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(webhookEventHeader, string(d.Event))
+	req.Header.Set(webhookTimestampHeader, timestamp)
+	req.Header.Set(webhookSignatureHeader, "sha256="+signWebhook(secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	// Drain a little so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+
+	status := resp.StatusCode
+	if status < 200 || status > 299 {
+		return &status, fmt.Errorf("unexpected status %d", status)
+	}
+	return &status, nil
+}
+
+// signWebhook returns the hex HMAC-SHA256 of "<timestamp>.<body>"
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// sealWebhookSecret encrypts a secret with AES-256-GCM for storage. Without a key the
+// secret is stored as is.
+func sealWebhookSecret(key []byte, secret string) (string, error) {
+	if key == nil {
+		return secret, nil
+	}
+	aead, err := webhookSecretCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(secret), nil)
+	return sealedSecretPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// openWebhookSecret returns the plaintext of a stored secret
+func openWebhookSecret(key []byte, stored string) (string, error) {
+	encoded, ok := strings.CutPrefix(stored, sealedSecretPrefix)
+	if !ok {
+		return stored, nil
+	}
+	if key == nil {
+		return "", errors.New("webhook secret is encrypted but WEBHOOK_SECRET_KEY is not set")
+	}
+	aead, err := webhookSecretCipher(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("malformed encrypted webhook secret")
+	}
+	secret, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", errors.New("failed to decrypt webhook secret, was WEBHOOK_SECRET_KEY changed?")
+	}
+	return string(secret), nil
+}
+
+func webhookSecretCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/onelineai/hana-news-api/internal/config"
+	"github.com/onelineai/hana-news-api/internal/model"
+	"github.com/onelineai/hana-news-api/internal/repository"
+	"github.com/onelineai/hana-news-api/internal/testdb"
+)
+
+// receivedWebhook is a request seen by a webhookReceiver
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookReceiver is an httptest receiver answering with a settable status
+type webhookReceiver struct {
+	*httptest.Server
+	status   atomic.Int32
+	requests chan receivedWebhook
+}
+
+func newWebhookReceiver(t *testing.T) *webhookReceiver {
+	t.Helper()
+	rcv := &webhookReceiver{requests: make(chan receivedWebhook, 16)}
+	rcv.status.Store(http.StatusOK)
+	rcv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rcv.requests <- receivedWebhook{header: r.Header.Clone(), body: body}
+		w.WriteHeader(int(rcv.status.Load()))
+	}))
+	t.Cleanup(rcv.Close)
+	return rcv
+}
+
+// received returns the requests received so far
+func (rcv *webhookReceiver) received() []receivedWebhook {
+	var got []receivedWebhook
+	for {
+		select {
+		case r := <-rcv.requests:
+			got = append(got, r)
+		default:
+			return got
+		}
+	}
+}
+
+// verifyWebhookSignature checks a request the way a receiver would
+func verifyWebhookSignature(t *testing.T, secret string, r receivedWebhook) {
+	t.Helper()
+	timestamp := r.header.Get("X-Webhook-Timestamp")
+	if _, err := strconv.ParseInt(timestamp, 10, 64); err != nil {
+		t.Fatalf("X-Webhook-Timestamp = %q, want unix seconds", timestamp)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + string(r.body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if got := r.header.Get("X-Webhook-Signature"); !hmac.Equal([]byte(got), []byte(want)) {
+		t.Fatalf("X-Webhook-Signature = %q, want %q", got, want)
+	}
+}
+
+func newTestSecretKey(t *testing.T) []byte {
+	t.Helper()
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func testWebhookLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestWebhookSend(t *testing.T) {
+	key := newTestSecretKey(t)
+	sealed, err := sealWebhookSecret(key, "whsec_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rcv := newWebhookReceiver(t)
+	s := NewWebhookService(nil, nil, config.WebhookConfig{Timeout: 5 * time.Second, SecretKey: key}, testWebhookLogger())
+	published := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+	d := model.WebhookDelivery{
+		ID:        42,
+		Event:     model.WebhookEventSynced,
+		Attempts:  1,
+		CreatedAt: published,
+		Webhook:   model.Webhook{ID: "wh-1", URL: rcv.URL, Secret: sealed, Tickers: []string{"7203.T"}},
+		News: &model.NewsEvent{
+			Seq:     7,
+			Source:  model.SourceJPMinkabu,
+			Tickers: []string{"7203.T", "6758.T"},
+			Item:    model.NewsListItem{ID: "news-1", Headline: "헤드라인", PublishedAt: published},
+		},
+	}
+
+	status, err := s.send(context.Background(), d)
+	if err != nil || status == nil || *status != http.StatusOK {
+		t.Fatalf("send = %v, %v; want 200", status, err)
+	}
+	got := rcv.received()
+	if len(got) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(got))
+	}
+	r := got[0]
+	verifyWebhookSignature(t, "whsec_test", r)
+	if r.header.Get("X-Webhook-Id") != "42" || r.header.Get("X-Webhook-Event") != "news.synced" {
+		t.Errorf("headers = %v", r.header)
+	}
+
+	var payload model.WebhookPayload
+	if err := json.Unmarshal(r.body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.ID != "42" || payload.WebhookID != "wh-1" || payload.Seq != 7 || payload.News.ID != "news-1" {
+		t.Errorf("payload = %+v", payload)
+	}
+	if len(payload.News.MatchedTickers) != 1 || payload.News.MatchedTickers[0] != "7203.T" {
+		t.Errorf("matched tickers = %v, want [7203.T]", payload.News.MatchedTickers)
+	}
+
+	// Non-2xx answers fail with their status
+	rcv.status.Store(http.StatusServiceUnavailable)
+	status, err = s.send(context.Background(), d)
+	if err == nil || status == nil || *status != http.StatusServiceUnavailable {
+		t.Fatalf("send = %v, %v; want 503 and an error", status, err)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		base     time.Duration
+		attempts int
+		want     time.Duration
+	}{
+		{30 * time.Second, 1, 30 * time.Second},
+		{30 * time.Second, 2, time.Minute},
+		{30 * time.Second, 5, 8 * time.Minute},
+		{30 * time.Second, 10, 256 * time.Minute},
+		{30 * time.Second, 11, maxWebhookBackoff},
+		{30 * time.Second, 1000, maxWebhookBackoff},
+		{time.Second, 0, time.Second},
+	}
+	for _, tt := range tests {
+		if got := webhookBackoff(tt.base, tt.attempts); got != tt.want {
+			t.Errorf("webhookBackoff(%v, %d) = %v, want %v", tt.base, tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookSecretSealing(t *testing.T) {
+	key := newTestSecretKey(t)
+	sealed, err := sealWebhookSecret(key, "whsec_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sealed, sealedSecretPrefix) || strings.Contains(sealed, "whsec_test") {
+		t.Fatalf("sealed secret = %q", sealed)
+	}
+	again, _ := sealWebhookSecret(key, "whsec_test")
+	if again == sealed {
+		t.Error("sealing twice gave the same ciphertext")
+	}
+
+	if got, err := openWebhookSecret(key, sealed); err != nil || got != "whsec_test" {
+		t.Errorf("open = %q, %v; want whsec_test", got, err)
+	}
+	// Secrets stored before a key was configured stay readable
+	if got, err := openWebhookSecret(key, "whsec_plain"); err != nil || got != "whsec_plain" {
+		t.Errorf("open plaintext = %q, %v; want whsec_plain", got, err)
+	}
+	if _, err := openWebhookSecret(newTestSecretKey(t), sealed); err == nil {
+		t.Error("opened with the wrong key")
+	}
+	if _, err := openWebhookSecret(nil, sealed); err == nil {
+		t.Error("opened without a key")
+	}
+	if plain, _ := sealWebhookSecret(nil, "whsec_test"); plain != "whsec_test" {
+		t.Errorf("sealing without a key = %q, want the secret", plain)
+	}
+}
+
+// webhookFixture is a webhook service on the test database delivering to a receiver
+type webhookFixture struct {
+	pool    *pgxpool.Pool
+	gold    *repository.GoldRepository
+	s       *WebhookService
+	rcv     *webhookReceiver
+	webhook *model.Webhook
+	next    int
+}
+
+func newWebhookFixture(t *testing.T, maxAttempts int) *webhookFixture {
+	t.Helper()
+	pool := testdb.New(t)
+	gold := repository.NewGoldRepository(pool)
+	logger := testWebhookLogger()
+	f := &webhookFixture{
+		pool: pool,
+		gold: gold,
+		rcv:  newWebhookReceiver(t),
+		s: NewWebhookService(gold, NewTickerService(gold, logger), config.WebhookConfig{
+			Workers:      4,
+			MaxAttempts:  maxAttempts,
+			RetryBackoff: 10 * time.Millisecond,
+			Timeout:      5 * time.Second,
+			SecretKey:    newTestSecretKey(t),
+		}, logger),
+	}
+
+	w, err := f.s.Create(context.Background(), model.WebhookRequest{URL: f.rcv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.webhook = w
+	return f
+}
+
+// syncNews writes a news row to gold and queues it for the webhook
+func (f *webhookFixture) syncNews(t *testing.T) {
+	t.Helper()
+	ctx := context.Background()
+	f.next++
+	published := time.Date(2026, 1, 29, 10, 20, 0, 0, time.UTC)
+	news := &model.TranslatedNews{
+		Source:             model.SourceJPMinkabu,
+		SourceNewsID:       "jp-" + strconv.Itoa(f.next),
+		OriginalHeadline:   "見出し",
+		TranslatedHeadline: "헤드라인",
+		Tickers:            []string{},
+		PublishedAt:        published,
+		ModelName:          "test-model",
+	}
+	if _, err := f.gold.UpsertNews(ctx, []*model.TranslatedNews{news}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.s.enqueue(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *webhookFixture) deliverDue(t *testing.T) []receivedWebhook {
+	t.Helper()
+	if err := f.s.deliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return f.rcv.received()
+}
+
+func (f *webhookFixture) pending(t *testing.T) int {
+	t.Helper()
+	var n int
+	if err := f.pool.QueryRow(context.Background(), `SELECT COUNT(*) FROM gold.webhook_deliveries`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestWebhookDeliverySigned(t *testing.T) {
+	f := newWebhookFixture(t, 3)
+
+	var stored string
+	err := f.pool.QueryRow(context.Background(),
+		`SELECT secret FROM gold.webhook_subscriptions WHERE id = $1`, f.webhook.ID).Scan(&stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored == f.webhook.Secret || !strings.HasPrefix(stored, sealedSecretPrefix) {
+		t.Fatalf("stored secret %q is not encrypted", stored)
+	}
+
+	f.syncNews(t)
+	got := f.deliverDue(t)
+	if len(got) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(got))
+	}
+	verifyWebhookSignature(t, f.webhook.Secret, got[0])
+	if n := f.pending(t); n != 0 {
+		t.Errorf("%d deliveries pending after success, want 0", n)
+	}
+}
+
+func TestWebhookDeliveryRetriesThenDeadLetters(t *testing.T) {
+	f := newWebhookFixture(t, 2)
+	f.rcv.status.Store(http.StatusServiceUnavailable)
+	f.syncNews(t)
+
+	if got := f.deliverDue(t); len(got) != 1 {
+		t.Fatalf("first pass sent %d requests, want 1", len(got))
+	}
+	var attempts, lastStatus int
+	var scheduled bool
+	err := f.pool.QueryRow(context.Background(), `
+		SELECT attempts, last_status, next_attempt_at > NOW() FROM gold.webhook_deliveries
+	`).Scan(&attempts, &lastStatus, &scheduled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 1 || lastStatus != http.StatusServiceUnavailable || !scheduled {
+		t.Fatalf("after one failure attempts=%d last_status=%d scheduled=%v", attempts, lastStatus, scheduled)
+	}
+
+	// The retry is not due before its backoff ends
+	time.Sleep(50 * time.Millisecond)
+	if got := f.deliverDue(t); len(got) != 1 {
+		t.Fatalf("retry sent %d requests, want 1", len(got))
+	}
+	if n := f.pending(t); n != 0 {
+		t.Errorf("%d deliveries pending after the last attempt, want 0", n)
+	}
+
+	letters, err := f.s.ListDeadLetters(context.Background(), model.WebhookDeadLetterFilter{WebhookID: f.webhook.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(letters.Data) != 1 {
+		t.Fatalf("%d dead letters, want 1", len(letters.Data))
+	}
+	l := letters.Data[0]
+	if l.Attempts != 2 || l.LastStatus == nil || *l.LastStatus != http.StatusServiceUnavailable {
+		t.Errorf("dead letter = %+v", l)
+	}
+}
+
+func TestWebhookDeliveryLeaseReclaim(t *testing.T) {
+	f := newWebhookFixture(t, 3)
+	f.syncNews(t)
+
+	// A replica claims the delivery and dies before recording the outcome
+	claimed, err := f.gold.ClaimWebhookDeliveries(context.Background(), 10, 200*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 1 {
+		t.Fatalf("claimed %d deliveries, want 1", len(claimed))
+	}
+
+	if got := f.deliverDue(t); len(got) != 0 {
+		t.Fatalf("leased delivery sent %d times, want 0", len(got))
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	got := f.deliverDue(t)
+	if len(got) != 1 {
+		t.Fatalf("after the lease sent %d requests, want 1", len(got))
+	}
+	if id := got[0].header.Get("X-Webhook-Id"); id != strconv.FormatInt(claimed[0].ID, 10) {
+		t.Errorf("X-Webhook-Id = %s, want %d", id, claimed[0].ID)
+	}
+	verifyWebhookSignature(t, f.webhook.Secret, got[0])
+	if n := f.pending(t); n != 0 {
+		t.Errorf("%d deliveries pending after reclaim, want 0", n)
+	}
+}
@@ -0,0 +1,100 @@
+// Package testdb provides a disposable Postgres database for tests. It is only
+// imported from _test.go files.
+package testdb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Env names the Postgres URL of a disposable database for the repository
+// tests, e.g. postgres://postgres@localhost:5432/hana_test. Its silver and gold schemas
+// are dropped and recreated; tests are skipped if it is unset.
+const Env = "TEST_DATABASE_URL"
+
+// silverFixtureSQL creates the silver tables the repositories read. Silver is owned by
+// the upstream ETL, so only the columns used here are declared.
+const silverFixtureSQL = `
+	CREATE SCHEMA silver;
+
+	CREATE TABLE silver.jp_minkabu_translated_news (
+		id                  BIGSERIAL PRIMARY KEY,
+		news_id             TEXT NOT NULL UNIQUE,
+		original_headline   TEXT NOT NULL,
+		original_story      TEXT,
+		translated_headline TEXT NOT NULL,
+		translated_story    TEXT,
+		providers           TEXT[] NOT NULL DEFAULT '{}',
+		topics              TEXT[] NOT NULL DEFAULT '{}',
+		tickers             TEXT[] NOT NULL DEFAULT '{}',
+		creation_time       TIMESTAMPTZ NOT NULL,
+		model_name          TEXT NOT NULL,
+		created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE silver.cn_wind_translated_news (
+		id                 BIGSERIAL PRIMARY KEY,
+		object_id          TEXT NOT NULL UNIQUE,
+		original_title     TEXT NOT NULL,
+		original_content   TEXT,
+		translated_title   TEXT NOT NULL,
+		translated_content TEXT,
+		publish_date       TIMESTAMPTZ NOT NULL,
+		source             TEXT,
+		sections           TEXT[] NOT NULL DEFAULT '{}',
+		wind_codes         TEXT[] NOT NULL DEFAULT '{}',
+		keywords           TEXT[] NOT NULL DEFAULT '{}',
+		model_name         TEXT NOT NULL,
+		created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+`
+
+// New connects to the test database and recreates the silver fixture tables and the
+// gold schema from migrations/
+func New(tb testing.TB) *pgxpool.Pool {
+	tb.Helper()
+	dsn := os.Getenv(Env)
+	if dsn == "" {
+		tb.Skipf("%s not set", Env)
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		tb.Fatalf("failed to connect to test database: %v", err)
+	}
+	tb.Cleanup(pool.Close)
+
+	if _, err := pool.Exec(ctx, `DROP SCHEMA IF EXISTS silver CASCADE; DROP SCHEMA IF EXISTS gold CASCADE`); err != nil {
+		tb.Fatalf("failed to drop schemas: %v", err)
+	}
+	if _, err := pool.Exec(ctx, silverFixtureSQL); err != nil {
+		tb.Fatalf("failed to create silver fixture: %v", err)
+	}
+
+	_, file, _, _ := runtime.Caller(0)
+	migrations, err := filepath.Glob(filepath.Join(filepath.Dir(file), "..", "..", "migrations", "*.sql"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	slices.Sort(migrations)
+	for _, path := range migrations {
+		sql, err := os.ReadFile(path)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		// Without arguments Exec uses the simple protocol, which runs multiple statements
+		if _, err := pool.Exec(ctx, string(sql)); err != nil {
+			tb.Fatalf("failed to apply %s: %v", filepath.Base(path), err)
+		}
+	}
+	return pool
+}
@@ -0,0 +1,10 @@
+-- Migration: Composite (updated_at, id) sync cursor
+-- Run on gold database (hana_securities)
+
+-- Silver row id of the last synced record, paired with last_synced_at.
+-- NULL for cursors written before this migration; the sync treats it as 0,
+-- which re-reads rows at exactly last_synced_at once (upserts are idempotent).
+ALTER TABLE gold.sync_metadata
+ADD COLUMN IF NOT EXISTS last_synced_id BIGINT;
+
+COMMENT ON COLUMN gold.sync_metadata.last_synced_id IS 'Silver id of the last synced row; keyset cursor is (last_synced_at, last_synced_id)';
@@ -0,0 +1,31 @@
+-- Migration: Sync run history
+-- Run on gold database (hana_securities)
+
+-- One row per source per batch sync run
+CREATE TABLE IF NOT EXISTS gold.sync_runs (
+    id                  BIGSERIAL PRIMARY KEY,
+    source              VARCHAR(20) NOT NULL,      -- 'jp_minkabu' | 'cn_wind'
+    status              VARCHAR(20) NOT NULL,      -- 'running' | 'succeeded' | 'failed'
+
+    started_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at         TIMESTAMPTZ,
+
+    rows_fetched        INT NOT NULL DEFAULT 0,    -- Rows read from silver
+    rows_upserted       INT NOT NULL DEFAULT 0,    -- Rows written to gold
+
+    -- Keyset cursor (updated_at, id) before and after the run
+    cursor_before_at    TIMESTAMPTZ,
+    cursor_before_id    BIGINT,
+    cursor_after_at     TIMESTAMPTZ,
+    cursor_after_id     BIGINT,
+
+    error               TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_runs_started_at
+    ON gold.sync_runs (started_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_sync_runs_source_started
+    ON gold.sync_runs (source, started_at DESC);
+
+COMMENT ON TABLE gold.sync_runs IS 'History of silver to gold sync runs per source';
@@ -0,0 +1,5 @@
+-- Migration: Record what started each sync run
+-- Run on gold database (hana_securities)
+
+ALTER TABLE gold.sync_runs
+ADD COLUMN IF NOT EXISTS trigger VARCHAR(20) NOT NULL DEFAULT 'scheduled';  -- 'scheduled' | 'manual' | 'backfill'
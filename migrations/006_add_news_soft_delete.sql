@@ -0,0 +1,14 @@
+-- Migration: Soft delete for news retracted or removed in silver
+-- Run on gold database (hana_securities)
+
+-- Set by the retraction sweep when the source row no longer exists in silver.
+-- Cleared again if the source row is re-synced.
+ALTER TABLE gold.translated_news
+ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- Listing only serves live rows
+CREATE INDEX IF NOT EXISTS idx_news_live_published_at
+    ON gold.translated_news (published_at DESC)
+    WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN gold.translated_news.deleted_at IS 'Set when the article was withdrawn or removed from silver; excluded from the API';
@@ -0,0 +1,43 @@
+-- Migration: Silver vs gold reconciliation reports
+-- Run on gold database (hana_securities)
+
+-- One row per source per reconciliation window
+CREATE TABLE IF NOT EXISTS gold.reconcile_reports (
+    id                  BIGSERIAL PRIMARY KEY,
+    source              VARCHAR(20) NOT NULL,      -- 'jp_minkabu' | 'cn_wind'
+    status              VARCHAR(20) NOT NULL,      -- 'running' | 'succeeded' | 'failed'
+
+    -- Window over silver updated_at / gold source_updated_at, [window_from, window_to)
+    window_from         TIMESTAMPTZ NOT NULL,
+    window_to           TIMESTAMPTZ NOT NULL,
+    repair              BOOLEAN NOT NULL DEFAULT FALSE,
+
+    started_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at         TIMESTAMPTZ,
+
+    silver_count        INT NOT NULL DEFAULT 0,
+    gold_count          INT NOT NULL DEFAULT 0,
+    missing_count       INT NOT NULL DEFAULT 0,
+    extra_count         INT NOT NULL DEFAULT 0,
+    stale_count         INT NOT NULL DEFAULT 0,
+    repaired_count      INT NOT NULL DEFAULT 0,
+
+    error               TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconcile_reports_started_at
+    ON gold.reconcile_reports (started_at DESC);
+
+-- Individual drifted rows of a report
+CREATE TABLE IF NOT EXISTS gold.reconcile_drift (
+    report_id           BIGINT NOT NULL REFERENCES gold.reconcile_reports(id) ON DELETE CASCADE,
+    source_news_id      VARCHAR(255) NOT NULL,
+    kind                VARCHAR(10) NOT NULL,      -- 'missing' | 'extra' | 'stale'
+    silver_updated_at   TIMESTAMPTZ,
+    gold_updated_at     TIMESTAMPTZ,
+    repaired            BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (report_id, source_news_id)
+);
+
+COMMENT ON TABLE gold.reconcile_reports IS 'Per-source drift between silver and gold over a time window';
+COMMENT ON TABLE gold.reconcile_drift IS 'Rows missing, extra or stale in gold for a reconciliation report';
@@ -0,0 +1,17 @@
+-- Migration: Content hash for no-op upsert detection
+-- Run on gold database (hana_securities)
+
+-- SHA-256 of the fields copied from silver. Upserts only rewrite a row when it changes,
+-- so synced_at now marks the last real content change. NULL for rows written before
+-- this migration; they are rewritten once on their next sync.
+ALTER TABLE gold.translated_news
+ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
+
+-- Sync runs report inserted / updated / unchanged rows separately.
+-- rows_upserted is kept as inserted + updated.
+ALTER TABLE gold.sync_runs
+ADD COLUMN IF NOT EXISTS rows_inserted INT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS rows_updated INT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS rows_unchanged INT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN gold.translated_news.content_hash IS 'SHA-256 of silver content fields; unchanged rows are not rewritten';
@@ -0,0 +1,13 @@
+-- Migration: Keyword search over translated Korean text
+-- Run on gold database (hana_securities)
+
+-- Trigram matching works on any script without a language-specific parser, so
+-- Korean substrings such as '반도체' match inside '반도체가' / '반도체주'.
+-- Requires a UTF-8 database with a non-C LC_CTYPE for multibyte trigrams.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_news_translated_headline_trgm
+    ON gold.translated_news USING GIN (translated_headline gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_news_translated_content_trgm
+    ON gold.translated_news USING GIN (translated_content gin_trgm_ops);
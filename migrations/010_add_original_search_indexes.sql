@@ -0,0 +1,15 @@
+-- Migration: Search over original Japanese/Chinese text and source IDs
+-- Run on gold database (hana_securities)
+
+-- CJK text has no word boundaries, so trigrams are used as for the Korean
+-- columns (see 009). Terms of one or two characters cannot use these indexes
+-- and fall back to scanning the rows left by the other filters.
+CREATE INDEX IF NOT EXISTS idx_news_original_headline_trgm
+    ON gold.translated_news USING GIN (original_headline gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_news_original_content_trgm
+    ON gold.translated_news USING GIN (original_content gin_trgm_ops);
+
+-- Lookup by news_id / object_id without knowing the source
+CREATE INDEX IF NOT EXISTS idx_news_source_news_id
+    ON gold.translated_news (source_news_id);
@@ -0,0 +1,11 @@
+-- Migration: Keyset pagination for the news listing
+-- Run on gold database (hana_securities)
+
+-- Cursors page on (published_at, id); the id tiebreaker keeps pages stable
+-- when several articles share a timestamp.
+CREATE INDEX IF NOT EXISTS idx_news_live_published_at_id
+    ON gold.translated_news (published_at DESC, id DESC)
+    WHERE deleted_at IS NULL;
+
+-- Superseded by the index above
+DROP INDEX IF EXISTS gold.idx_news_live_published_at;
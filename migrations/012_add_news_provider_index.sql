@@ -0,0 +1,7 @@
+-- Migration: Provider filter on the news listing
+-- Run on gold database (hana_securities)
+
+-- topics and keywords already have GIN indexes (001)
+CREATE INDEX IF NOT EXISTS idx_news_provider
+    ON gold.translated_news (provider)
+    WHERE deleted_at IS NULL;
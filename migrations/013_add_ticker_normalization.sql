@@ -0,0 +1,68 @@
+-- Migration: Canonical tickers and the ticker mapping table
+-- Run on gold database (hana_securities)
+
+-- Canonical form is "<code>.<suffix>" as used by Wind: 7203.T, 600519.SH, 000001.SZ, 830799.BJ.
+-- Mirrors model.NormalizeTicker; keep both in sync.
+CREATE OR REPLACE FUNCTION gold.normalize_ticker(raw TEXT) RETURNS TEXT
+LANGUAGE sql IMMUTABLE AS $$
+    WITH s AS (
+        SELECT upper(regexp_replace(raw, '\s', '', 'g')) AS t
+    ), parts AS (
+        SELECT t,
+               CASE WHEN t ~ '^.+\.[^.]*$' THEN regexp_replace(t, '^(.*)\.[^.]*$', '\1') END AS code,
+               CASE WHEN t ~ '^.+\.[^.]*$' THEN regexp_replace(t, '^.*\.', '') END AS suffix
+        FROM s
+    )
+    SELECT CASE
+        WHEN suffix IS NOT NULL THEN
+            CASE
+                WHEN suffix IN ('T', 'JP', 'TYO', 'TSE') THEN code || '.T'
+                WHEN suffix IN ('SH', 'SS', 'SHG') THEN code || '.SH'
+                WHEN suffix IN ('SZ', 'SHE') THEN code || '.SZ'
+                WHEN suffix = 'BJ' THEN code || '.BJ'
+                WHEN suffix = 'HK' THEN code || '.HK'
+                ELSE t
+            END
+        WHEN t ~ '^[69][0-9]{5}$' THEN t || '.SH'
+        WHEN t ~ '^[023][0-9]{5}$' THEN t || '.SZ'
+        WHEN t ~ '^[48][0-9]{5}$' THEN t || '.BJ'
+        WHEN t ~ '^[0-9]{3}[0-9A-Z]$' THEN t || '.T'
+        ELSE t
+    END
+    FROM parts
+$$;
+
+-- Normalizes a ticker array, dropping empties and duplicates while keeping order
+CREATE OR REPLACE FUNCTION gold.normalize_tickers(raw TEXT[]) RETURNS TEXT[]
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT COALESCE(array_agg(t ORDER BY ord), '{}')
+    FROM (
+        SELECT gold.normalize_ticker(r) AS t, MIN(ord) AS ord
+        FROM unnest(raw) WITH ORDINALITY AS u(r, ord)
+        GROUP BY 1
+    ) n
+    WHERE t <> ''
+$$;
+
+-- Bring rows synced before normalization in line with new syncs. The content hash
+-- is cleared so the next sync rewrites these rows with a fresh one.
+UPDATE gold.translated_news
+SET tickers = gold.normalize_tickers(tickers), content_hash = NULL
+WHERE tickers IS DISTINCT FROM gold.normalize_tickers(tickers);
+
+-- Ticker mapping table, maintained by hand or by import.
+-- ISINs and aliases (e.g. vendor-specific codes) resolve to the canonical ticker in filters.
+CREATE TABLE IF NOT EXISTS gold.instruments (
+    ticker      VARCHAR(32) PRIMARY KEY,               -- canonical ticker
+    exchange    VARCHAR(16) NOT NULL,                  -- TSE, SSE, SZSE, BSE, HKEX
+    isin        VARCHAR(12) UNIQUE,
+    name_ko     TEXT,
+    name_ja     TEXT,
+    name_zh     TEXT,
+    aliases     TEXT[] NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE gold.instruments IS 'Ticker mapping table: canonical ticker, exchange, ISIN and company names';
+COMMENT ON COLUMN gold.instruments.aliases IS 'Other codes for the same instrument, resolved to ticker in API filters';
@@ -0,0 +1,38 @@
+-- Migration: Instrument master populated from sync and CSV import
+-- Run on gold database (hana_securities)
+
+-- Span of news mentioning the ticker; NULL for imported instruments not seen yet
+ALTER TABLE gold.instruments
+ADD COLUMN IF NOT EXISTS first_seen_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMPTZ;
+
+-- Register tickers already in gold; later ones are added by each sync
+INSERT INTO gold.instruments (ticker, exchange, first_seen_at, last_seen_at)
+SELECT t,
+       CASE substring(t FROM '\.([^.]*)$')
+           WHEN 'T' THEN 'TSE'
+           WHEN 'SH' THEN 'SSE'
+           WHEN 'SZ' THEN 'SZSE'
+           WHEN 'BJ' THEN 'BSE'
+           WHEN 'HK' THEN 'HKEX'
+           ELSE ''
+       END,
+       MIN(published_at), MAX(published_at)
+FROM gold.translated_news, unnest(tickers) AS t
+WHERE deleted_at IS NULL
+GROUP BY t
+ON CONFLICT (ticker) DO UPDATE SET
+    first_seen_at = EXCLUDED.first_seen_at,
+    last_seen_at = EXCLUDED.last_seen_at;
+
+-- Autocomplete on ticker prefix and company names (pg_trgm from 009)
+CREATE INDEX IF NOT EXISTS idx_instruments_ticker_trgm
+    ON gold.instruments USING GIN (ticker gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_instruments_name_ko_trgm
+    ON gold.instruments USING GIN (name_ko gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_instruments_name_ja_trgm
+    ON gold.instruments USING GIN (name_ja gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_instruments_name_zh_trgm
+    ON gold.instruments USING GIN (name_zh gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_instruments_aliases_gin
+    ON gold.instruments USING GIN (aliases);
@@ -0,0 +1,31 @@
+-- Migration: Sync sequence for real-time news push
+-- Run on gold database (hana_securities)
+
+-- Bumped on every insert and real content update. Writers hold an advisory lock
+-- while assigning values, so sequence order equals commit order and stream
+-- clients can resume from the last seen value (SSE Last-Event-ID).
+CREATE SEQUENCE IF NOT EXISTS gold.news_sync_seq;
+
+ALTER TABLE gold.translated_news
+ADD COLUMN IF NOT EXISTS sync_seq BIGINT;
+
+-- Number existing rows in sync order
+UPDATE gold.translated_news n
+SET sync_seq = o.seq
+FROM (
+    SELECT id, row_number() OVER (ORDER BY synced_at, id) AS seq
+    FROM gold.translated_news
+) o
+WHERE n.id = o.id AND n.sync_seq IS NULL;
+
+SELECT setval('gold.news_sync_seq', COALESCE(MAX(sync_seq), 0) + 1, false)
+FROM gold.translated_news;
+
+ALTER TABLE gold.translated_news
+ALTER COLUMN sync_seq SET DEFAULT nextval('gold.news_sync_seq'),
+ALTER COLUMN sync_seq SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_news_sync_seq
+    ON gold.translated_news (sync_seq);
+
+COMMENT ON COLUMN gold.translated_news.sync_seq IS 'Commit-ordered sequence of inserts and content updates; SSE event ID';
@@ -0,0 +1,60 @@
+-- Migration: Outbound webhooks for newly synced news
+-- Run on gold database (hana_securities)
+
+-- Receivers and the news they want; last_seq is how far news has been queued
+CREATE TABLE IF NOT EXISTS gold.webhook_subscriptions (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    url                 TEXT NOT NULL,
+    secret              TEXT NOT NULL,             -- HMAC-SHA256 signing key
+    description         TEXT,
+
+    -- Filters; empty arrays match everything
+    source              VARCHAR(20),               -- 'jp_minkabu' | 'cn_wind' | NULL for all
+    tickers             TEXT[] NOT NULL DEFAULT '{}',
+    topics              TEXT[] NOT NULL DEFAULT '{}',
+
+    active              BOOLEAN NOT NULL DEFAULT TRUE,
+    last_seq            BIGINT NOT NULL DEFAULT 0, -- gold.translated_news.sync_seq queued up to
+
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Pending deliveries; rows are deleted once delivered or moved to dead letters
+CREATE TABLE IF NOT EXISTS gold.webhook_deliveries (
+    id                  BIGSERIAL PRIMARY KEY,
+    subscription_id     UUID NOT NULL REFERENCES gold.webhook_subscriptions(id) ON DELETE CASCADE,
+    news_id             UUID NOT NULL,
+    event               VARCHAR(20) NOT NULL,      -- 'news.synced' | 'news.replay'
+    attempts            INT NOT NULL DEFAULT 0,
+    next_attempt_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_status         INT,                       -- HTTP status of the last attempt
+    last_error          TEXT,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at
+    ON gold.webhook_deliveries (next_attempt_at);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id
+    ON gold.webhook_deliveries (subscription_id);
+
+-- Deliveries that exhausted their retries
+CREATE TABLE IF NOT EXISTS gold.webhook_dead_letters (
+    id                  BIGINT PRIMARY KEY,        -- original delivery ID
+    subscription_id     UUID NOT NULL REFERENCES gold.webhook_subscriptions(id) ON DELETE CASCADE,
+    news_id             UUID NOT NULL,
+    event               VARCHAR(20) NOT NULL,
+    attempts            INT NOT NULL,
+    last_status         INT,
+    last_error          TEXT,
+    created_at          TIMESTAMPTZ NOT NULL,
+    failed_at           TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_dead_letters_subscription_id
+    ON gold.webhook_dead_letters (subscription_id, failed_at DESC);
+
+COMMENT ON TABLE gold.webhook_subscriptions IS 'Outbound webhook receivers of newly synced news';
+COMMENT ON TABLE gold.webhook_deliveries IS 'Webhook deliveries waiting for their next attempt';
+COMMENT ON TABLE gold.webhook_dead_letters IS 'Webhook deliveries that failed after all retries';
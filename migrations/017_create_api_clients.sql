@@ -0,0 +1,37 @@
+-- Migration: API clients and their keys
+-- Run on gold database (hana_securities)
+
+-- B2B clients calling the API
+CREATE TABLE IF NOT EXISTS gold.api_clients (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name                TEXT NOT NULL,
+
+    -- Licensed news sources ('jp_minkabu' | 'cn_wind'); empty means all
+    sources             TEXT[] NOT NULL DEFAULT '{}',
+    admin               BOOLEAN NOT NULL DEFAULT FALSE,    -- may call /v1/admin
+    disabled            BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- API keys; a client may hold several during rotation. Only SHA-256 hashes are stored.
+CREATE TABLE IF NOT EXISTS gold.api_client_keys (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    client_id           UUID NOT NULL REFERENCES gold.api_clients(id) ON DELETE CASCADE,
+    prefix              VARCHAR(16) NOT NULL,              -- leading characters, to tell keys apart
+    key_hash            CHAR(64) NOT NULL,                 -- hex SHA-256 of the key
+    expires_at          TIMESTAMPTZ,
+    revoked_at          TIMESTAMPTZ,
+    last_used_at        TIMESTAMPTZ,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_client_keys_key_hash
+    ON gold.api_client_keys (key_hash);
+
+CREATE INDEX IF NOT EXISTS idx_api_client_keys_client_id
+    ON gold.api_client_keys (client_id, created_at);
+
+COMMENT ON TABLE gold.api_clients IS 'API clients with their licensed sources';
+COMMENT ON TABLE gold.api_client_keys IS 'Hashed API keys of clients, with expiry and revocation';
@@ -0,0 +1,26 @@
+-- Migration: shared rate limit buckets and client quota counters
+-- Run on gold database (hana_securities)
+
+-- Token buckets for RATE_LIMIT_STORE=postgres. Unlogged: losing them on a crash
+-- only refills every bucket.
+CREATE UNLOGGED TABLE IF NOT EXISTS gold.rate_limit_buckets (
+    key                 TEXT PRIMARY KEY,                  -- 'client:<id>' or 'ip:<address>'
+    tokens              DOUBLE PRECISION NOT NULL,
+    updated_at          TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at
+    ON gold.rate_limit_buckets (updated_at);
+
+-- Requests per client and day (Asia/Seoul)
+CREATE TABLE IF NOT EXISTS gold.api_client_quota (
+    client_id           UUID NOT NULL REFERENCES gold.api_clients(id) ON DELETE CASCADE,
+    day                 DATE NOT NULL,
+    requests            BIGINT NOT NULL DEFAULT 0,         -- requests let through
+    rate_limited        BIGINT NOT NULL DEFAULT 0,         -- requests rejected with 429
+
+    PRIMARY KEY (client_id, day)
+);
+
+COMMENT ON TABLE gold.rate_limit_buckets IS 'Token buckets shared by API replicas';
+COMMENT ON TABLE gold.api_client_quota IS 'Daily request counters of API clients';
@@ -0,0 +1,17 @@
+-- Migration: per-request usage events of API clients
+-- Run on gold database (hana_securities)
+
+CREATE TABLE IF NOT EXISTS gold.usage_events (
+    id                  BIGSERIAL PRIMARY KEY,
+    client_id           UUID NOT NULL REFERENCES gold.api_clients(id),
+    endpoint            TEXT NOT NULL,                     -- method and route, e.g. 'GET /v1/news/{id}'
+    status              INTEGER NOT NULL,
+    result_count        INTEGER NOT NULL DEFAULT 0,        -- articles returned
+    news_ids            UUID[] NOT NULL DEFAULT '{}',      -- articles whose detail was returned
+    created_at          TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_events_created_at
+    ON gold.usage_events (created_at, client_id);
+
+COMMENT ON TABLE gold.usage_events IS 'Metered API requests of clients, for billing reports';
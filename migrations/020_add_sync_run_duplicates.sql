@@ -0,0 +1,7 @@
+-- Migration: Count in-batch duplicates of sync runs
+-- Run on gold database (hana_securities)
+
+-- Bulk upserts keep only the newest copy of a row that appears twice in one batch.
+-- The dropped copies were previously counted as unchanged.
+ALTER TABLE gold.sync_runs
+ADD COLUMN IF NOT EXISTS rows_duplicate INT NOT NULL DEFAULT 0;
@@ -0,0 +1,16 @@
+-- Migration: Assign sync_seq after commit
+-- Run on gold database (hana_securities)
+
+-- Upserts used to take a global advisory lock for their whole transaction so that
+-- sync_seq order matched commit order. They now write rows with sync_seq NULL and,
+-- after committing, number them in a short step that alone holds the lock.
+-- Stream and webhook readers skip rows that are not numbered yet.
+ALTER TABLE gold.translated_news
+ALTER COLUMN sync_seq DROP DEFAULT,
+ALTER COLUMN sync_seq DROP NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_news_sync_seq_pending
+    ON gold.translated_news (synced_at, id)
+    WHERE sync_seq IS NULL;
+
+COMMENT ON COLUMN gold.translated_news.sync_seq IS 'Commit-ordered sequence of inserts and content updates, NULL until assigned after commit; SSE event ID';
@@ -0,0 +1,28 @@
+-- Migration: Bigram search for two-character terms
+-- Run on gold database (hana_securities)
+
+-- Trigram indexes (009, 010) cannot serve terms shorter than three characters,
+-- yet many Korean words ('금리', '환율') and CJK words ('株価') are two characters
+-- long. These expression indexes hold the distinct lower-cased character pairs of
+-- each text, so a two-character term is a single array containment lookup.
+CREATE OR REPLACE FUNCTION gold.text_bigrams(t TEXT) RETURNS TEXT[]
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT COALESCE(array_agg(DISTINCT b), '{}')
+    FROM generate_series(1, char_length(t) - 1) AS i,
+         LATERAL substr(lower(t), i, 2) AS b
+    WHERE b !~ '\s'
+$$;
+
+CREATE INDEX IF NOT EXISTS idx_news_translated_headline_bigram
+    ON gold.translated_news USING GIN (gold.text_bigrams(translated_headline));
+
+CREATE INDEX IF NOT EXISTS idx_news_translated_content_bigram
+    ON gold.translated_news USING GIN (gold.text_bigrams(translated_content));
+
+CREATE INDEX IF NOT EXISTS idx_news_original_headline_bigram
+    ON gold.translated_news USING GIN (gold.text_bigrams(original_headline));
+
+CREATE INDEX IF NOT EXISTS idx_news_original_content_bigram
+    ON gold.translated_news USING GIN (gold.text_bigrams(original_content));
+
+COMMENT ON FUNCTION gold.text_bigrams(TEXT) IS 'Distinct lower-cased two-character substrings without whitespace, for two-character search terms';